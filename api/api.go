@@ -0,0 +1,250 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// DefaultRepo is Go source repository API files are fetched from when no
+// installed toolchain has them, e.g. toolchains installed through GOPROXY.
+const DefaultRepo = "https://go.googlesource.com/go/+/refs/heads/master"
+
+var ErrNotFound = errors.New("API file not found")
+
+// Feature is an exported element of standard library API.
+type Feature struct {
+	Pkg string
+	// Decl is declaration, e.g. "func Clear(interface{})"
+	Decl string
+	// Contexts are platforms feature is limited to, e.g. linux-amd64-cgo, empty on all platforms
+	Contexts []string
+	// Version is minor release which added feature, e.g. go1.21
+	Version string
+}
+
+// Platforms returns operating systems of feature contexts.
+func (f Feature) Platforms() []string {
+	var platforms []string
+	for _, c := range f.Contexts {
+		goos, _, _ := strings.Cut(c, "-")
+		if !slices.Contains(platforms, goos) {
+			platforms = append(platforms, goos)
+		}
+	}
+	return platforms
+}
+
+// Source reads API files of Go releases from GOROOT of installed toolchains,
+// or fetches them from Go source repository and caches them.
+type Source struct {
+	goRoots  []string
+	cacheDir string
+	repo     string
+	http     *http.Client
+}
+
+// NewSource returns source of API files looking into goRoots first.
+// Files fetched from repo are cached in cacheDir.
+func NewSource(goRoots []string, cacheDir, repo string) *Source {
+	return &Source{
+		goRoots:  goRoots,
+		cacheDir: cacheDir,
+		repo:     strings.TrimSuffix(repo, "/"),
+		http:     &http.Client{Timeout: time.Minute},
+	}
+}
+
+// Added returns features added by minor releases after from up to to, inclusive,
+// sorted by package, version and declaration. Patch and pre-release parts of versions are ignored.
+func (s *Source) Added(from, to string) ([]Feature, error) {
+	fv, err := gover.Parse(from)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := gover.Parse(to)
+	if err != nil {
+		return nil, err
+	}
+	if fv.Lang().Compare(tv.Lang()) >= 0 {
+		return nil, fmt.Errorf("%s is not older than %s", fv.Lang(), tv.Lang())
+	}
+
+	var features []Feature
+	for minor := fv.Minor + 1; minor <= tv.Minor; minor++ {
+		release, err := s.Release(minor)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, release...)
+	}
+	slices.SortStableFunc(features, func(a, b Feature) int {
+		if c := strings.Compare(a.Pkg, b.Pkg); c != 0 {
+			return c
+		}
+		if c := gover.Compare(a.Version, b.Version); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Decl, b.Decl)
+	})
+	return features, nil
+}
+
+// Release returns features added by Go 1.minor.
+func (s *Source) Release(minor int) ([]Feature, error) {
+	version := fmt.Sprintf("go1.%d", minor)
+	if minor == 0 {
+		version = "go1"
+	}
+	data, err := s.read(version + ".txt")
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(data), version)
+}
+
+func (s *Source) read(name string) ([]byte, error) {
+	for _, goRoot := range s.goRoots {
+		data, err := os.ReadFile(filepath.Join(goRoot, "api", name))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("read API file: %w", err)
+		}
+	}
+
+	cached := filepath.Join(s.cacheDir, name)
+	if data, err := os.ReadFile(cached); err == nil {
+		return data, nil
+	}
+	data, err := s.fetch(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("cache API file: %w", err)
+	}
+	// released API files never change
+	if err := os.WriteFile(cached, data, 0644); err != nil {
+		return nil, fmt.Errorf("cache API file: %w", err)
+	}
+	return data, nil
+}
+
+// fetch downloads API file, Gitiles serves file contents base64 encoded.
+func (s *Source) fetch(name string) ([]byte, error) {
+	url := s.repo + "/api/" + name + "?format=TEXT"
+	res, err := s.http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch API file: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch API file %s: %s", url, res.Status)
+	}
+	data, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, res.Body))
+	if err != nil {
+		return nil, fmt.Errorf("decode API file %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// Parse reads features of API file added by version. Features listed
+// for several platforms are merged into one with all the contexts.
+func Parse(r io.Reader, version string) ([]Feature, error) {
+	type key struct{ pkg, decl string }
+	var features []Feature
+	index := make(map[key]int)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		rest, ok := strings.CutPrefix(line, "pkg ")
+		if !ok {
+			continue
+		}
+		pkg, decl, ok := strings.Cut(rest, ", ")
+		if !ok {
+			continue
+		}
+		context := ""
+		if i := strings.Index(pkg, " ("); i >= 0 {
+			context = strings.TrimSuffix(pkg[i+2:], ")")
+			pkg = pkg[:i]
+		}
+		// proposal issue, e.g. "#56102"
+		if i := strings.LastIndex(decl, " #"); i >= 0 && isNumber(decl[i+2:]) {
+			decl = decl[:i]
+		}
+
+		k := key{pkg, decl}
+		i, seen := index[k]
+		if !seen {
+			index[k] = len(features)
+			features = append(features, Feature{Pkg: pkg, Decl: decl, Version: version})
+			i = len(features) - 1
+			if context != "" {
+				features[i].Contexts = []string{context}
+			}
+			continue
+		}
+		switch f := &features[i]; {
+		case context == "" || len(f.Contexts) == 0:
+			// available on all platforms
+			f.Contexts = nil
+		case !slices.Contains(f.Contexts, context):
+			f.Contexts = append(f.Contexts, context)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read API file: %w", err)
+	}
+	return features, nil
+}
+
+// Match reports whether package matches one of patterns, pattern ending
+// with "/..." matches package and its subpackages. Empty patterns match any package.
+func Match(pkg string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if base, ok := strings.CutSuffix(p, "/..."); ok {
+			if pkg == base || strings.HasPrefix(pkg, base+"/") {
+				return true
+			}
+		} else if pkg == p {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const go122 = `pkg cmp, func Or[$0 comparable](...$0) $0 #60204
+pkg net/http, method (*Request) PathValue(string) string #61410
+pkg syscall (linux-386), const SYS_X = 1
+pkg syscall (linux-386-cgo), const SYS_X = 1
+pkg syscall (windows-amd64), const SYS_X = 1
+pkg syscall (darwin-amd64), func Y()
+pkg syscall, func Y()
+`
+
+const go123 = `pkg iter, func Pull[$0 interface{}](Seq[$0]) (func() ($0, bool), func()) #61897
+pkg net/http, func ParseCookie(string) ([]*Cookie, error) #66008
+`
+
+func TestParse(t *testing.T) {
+	features, err := Parse(strings.NewReader(go122), "go1.22")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []Feature{
+		{Pkg: "cmp", Decl: "func Or[$0 comparable](...$0) $0", Version: "go1.22"},
+		{Pkg: "net/http", Decl: "method (*Request) PathValue(string) string", Version: "go1.22"},
+		{Pkg: "syscall", Decl: "const SYS_X = 1", Contexts: []string{"linux-386", "linux-386-cgo", "windows-amd64"}, Version: "go1.22"},
+		{Pkg: "syscall", Decl: "func Y()", Version: "go1.22"},
+	}
+	if len(features) != len(want) {
+		t.Fatalf("features = %+v, want %+v", features, want)
+	}
+	for i, f := range features {
+		w := want[i]
+		if f.Pkg != w.Pkg || f.Decl != w.Decl || f.Version != w.Version || !slices.Equal(f.Contexts, w.Contexts) {
+			t.Errorf("features[%d] = %+v, want %+v", i, f, w)
+		}
+	}
+	if got := features[2].Platforms(); !slices.Equal(got, []string{"linux", "windows"}) {
+		t.Errorf("Platforms = %v, want [linux windows]", got)
+	}
+}
+
+func TestSourceAdded(t *testing.T) {
+	goRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(goRoot, "api"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(goRoot, "api", "go1.22.txt"), []byte(go122), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/go1.23.txt" || r.URL.Query().Get("format") != "TEXT" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(base64.StdEncoding.EncodeToString([]byte(go123))))
+	}))
+	defer srv.Close()
+	cacheDir := filepath.Join(t.TempDir(), "api")
+
+	src := NewSource([]string{t.TempDir(), goRoot}, cacheDir, srv.URL+"/")
+	features, err := src.Added("1.21.5", "go1.23rc1")
+	if err != nil {
+		t.Fatalf("Added: %v", err)
+	}
+	var got []string
+	for _, f := range features {
+		got = append(got, f.Version+" "+f.Pkg)
+	}
+	want := []string{"go1.22 cmp", "go1.23 iter", "go1.22 net/http", "go1.23 net/http", "go1.22 syscall", "go1.22 syscall"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Added = %v, want %v", got, want)
+	}
+
+	// fetched file is cached
+	if _, err := NewSource(nil, cacheDir, srv.URL).Release(23); err != nil || requests != 1 {
+		t.Errorf("Release(23) from cache: err = %v, requests = %d, want 1", err, requests)
+	}
+	if _, err := src.Release(99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Release(99): err = %v, want ErrNotFound", err)
+	}
+	if _, err := src.Added("1.23", "1.22"); err == nil {
+		t.Error("Added(1.23, 1.22): err = nil")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pkg      string
+		patterns []string
+		want     bool
+	}{
+		{"net/http", nil, true},
+		{"net/http", []string{"net/http"}, true},
+		{"net/http/httptest", []string{"net/http"}, false},
+		{"net/http/httptest", []string{"net/http/..."}, true},
+		{"net/http", []string{"net/http/..."}, true},
+		{"net/netip", []string{"os", "net/http/..."}, false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pkg, tt.patterns); got != tt.want {
+			t.Errorf("Match(%s, %v) = %v, want %v", tt.pkg, tt.patterns, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Key identifies benchmark.
+type Key struct {
+	Pkg string
+	// Name of benchmark with "Benchmark" prefix and GOMAXPROCS suffix, e.g. BenchmarkParse-8
+	Name string
+}
+
+// Set is a collection of benchmark results in Go benchmark format.
+type Set struct {
+	// Keys of benchmarks in order of appearance
+	Keys []Key
+	// Units of measurements in order of appearance, e.g. ns/op, B/op
+	Units  []string
+	values map[Key]map[string][]float64
+}
+
+// NewSet returns empty set.
+func NewSet() *Set {
+	return &Set{values: make(map[Key]map[string][]float64)}
+}
+
+// ParseFile reads results saved in file.
+func ParseFile(file string) (*Set, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open benchmark results: %w", err)
+	}
+	defer f.Close()
+	s := NewSet()
+	if err := s.Parse(f); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+	return s, nil
+}
+
+// Parse adds results from output of 'go test -bench'. Lines other
+// than benchmark results and "pkg" configuration are ignored.
+func (s *Set) Parse(r io.Reader) error {
+	pkg := ""
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if value, ok := strings.CutPrefix(line, "pkg:"); ok {
+			pkg = strings.TrimSpace(value)
+			continue
+		}
+		if !strings.HasPrefix(line, "Benchmark") {
+			continue
+		}
+		fields := strings.Fields(line)
+		// name, iterations and at least one value with unit
+		if len(fields) < 4 || len(fields)%2 != 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		key := Key{Pkg: pkg, Name: fields[0]}
+		for i := 2; i < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				break
+			}
+			s.add(key, fields[i+1], v)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("read benchmark results: %w", err)
+	}
+	return nil
+}
+
+// Values returns measurements of benchmark in unit.
+func (s *Set) Values(key Key, unit string) []float64 {
+	return s.values[key][unit]
+}
+
+func (s *Set) add(key Key, unit string, v float64) {
+	units, ok := s.values[key]
+	if !ok {
+		units = make(map[string][]float64)
+		s.values[key] = units
+		s.Keys = append(s.Keys, key)
+	}
+	if !slices.Contains(s.Units, unit) {
+		s.Units = append(s.Units, unit)
+	}
+	units[unit] = append(units[unit], v)
+}
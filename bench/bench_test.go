@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bench
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: example.com/parse
+cpu: AMD EPYC
+BenchmarkParse-8     	  100000	     10500 ns/op	    1024 B/op	      12 allocs/op
+BenchmarkParse/small-8	 2000000	       612.5 ns/op
+BenchmarkSpeed-8     	    1000	   1250000 ns/op	 104.86 MB/s
+--- BENCH: BenchmarkSpeed-8
+    speed_test.go:12: warming up
+PASS
+ok  	example.com/parse	3.104s
+pkg: example.com/format
+BenchmarkParse-8     	  100000	     20500 ns/op
+BenchmarkBroken-8    	  fast	     20500 ns/op
+PASS
+`
+
+func TestParse(t *testing.T) {
+	s := NewSet()
+	for range 2 {
+		if err := s.Parse(strings.NewReader(output)); err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+	}
+	wantKeys := []Key{
+		{"example.com/parse", "BenchmarkParse-8"},
+		{"example.com/parse", "BenchmarkParse/small-8"},
+		{"example.com/parse", "BenchmarkSpeed-8"},
+		{"example.com/format", "BenchmarkParse-8"},
+	}
+	if !slices.Equal(s.Keys, wantKeys) {
+		t.Errorf("Keys = %v, want %v", s.Keys, wantKeys)
+	}
+	if want := []string{"ns/op", "B/op", "allocs/op", "MB/s"}; !slices.Equal(s.Units, want) {
+		t.Errorf("Units = %v, want %v", s.Units, want)
+	}
+	if got := s.Values(wantKeys[0], "allocs/op"); !slices.Equal(got, []float64{12, 12}) {
+		t.Errorf("allocs/op = %v, want [12 12]", got)
+	}
+	if got := s.Values(wantKeys[1], "ns/op"); !slices.Equal(got, []float64{612.5, 612.5}) {
+		t.Errorf("ns/op = %v, want [612.5 612.5]", got)
+	}
+	if got := s.Values(wantKeys[3], "B/op"); got != nil {
+		t.Errorf("missing unit = %v, want nil", got)
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "go1.22.5.txt")
+	if err := os.WriteFile(file, []byte(output), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s, err := ParseFile(file)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if len(s.Keys) != 4 {
+		t.Errorf("Keys = %v, want 4", s.Keys)
+	}
+	if _, err := ParseFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("missing file: err = nil")
+	}
+}
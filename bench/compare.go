@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bench
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Summary describes measurements of benchmark in one set.
+type Summary struct {
+	Median float64
+	// Spread is the largest deviation from median relative to median
+	Spread float64
+	// N is number of measurements, 0 if benchmark is missing in the set
+	N int
+}
+
+// Row compares measurements of benchmark in old and new set.
+type Row struct {
+	Key      Key
+	Old, New Summary
+	// Delta is relative change of median
+	Delta float64
+	// P is p-value of Mann-Whitney U test
+	P float64
+}
+
+// Comparable reports whether benchmark has measurements in both sets.
+func (r Row) Comparable() bool {
+	return r.Old.N > 0 && r.New.N > 0
+}
+
+// Significant reports whether difference is statistically significant.
+func (r Row) Significant() bool {
+	return r.Comparable() && r.P < Alpha
+}
+
+// Table compares benchmarks measured in one unit.
+type Table struct {
+	Unit string
+	Rows []Row
+	// OldGeomean and NewGeomean are geometric means of medians of comparable rows
+	OldGeomean, NewGeomean float64
+}
+
+// GeomeanDelta returns relative change of geometric mean, 0 if it is unknown.
+func (t Table) GeomeanDelta() float64 {
+	if t.OldGeomean == 0 {
+		return 0
+	}
+	return t.NewGeomean/t.OldGeomean - 1
+}
+
+// Compare returns table per unit comparing benchmarks of old and new sets.
+func Compare(old, new *Set) []Table {
+	units := slices.Clone(old.Units)
+	for _, u := range new.Units {
+		if !slices.Contains(units, u) {
+			units = append(units, u)
+		}
+	}
+	keys := slices.Clone(old.Keys)
+	for _, k := range new.Keys {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+
+	tables := make([]Table, 0, len(units))
+	for _, unit := range units {
+		t := Table{Unit: unit}
+		logOld, logNew, n := 0.0, 0.0, 0
+		for _, key := range keys {
+			x, y := old.Values(key, unit), new.Values(key, unit)
+			if len(x) == 0 && len(y) == 0 {
+				continue
+			}
+			row := Row{Key: key, Old: summarize(x), New: summarize(y), P: 1}
+			if row.Comparable() {
+				row.P = MannWhitneyU(x, y)
+				if row.Old.Median != 0 {
+					row.Delta = row.New.Median/row.Old.Median - 1
+				}
+				if row.Old.Median > 0 && row.New.Median > 0 {
+					logOld += math.Log(row.Old.Median)
+					logNew += math.Log(row.New.Median)
+					n++
+				}
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		if n > 0 {
+			t.OldGeomean = math.Exp(logOld / float64(n))
+			t.NewGeomean = math.Exp(logNew / float64(n))
+		}
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+func summarize(values []float64) Summary {
+	return Summary{Median: Median(values), Spread: Spread(values), N: len(values)}
+}
+
+// LowerIsBetter reports whether decrease of measurements in unit is an improvement.
+// Rates like MB/s are better when higher.
+func LowerIsBetter(unit string) bool {
+	return !strings.HasSuffix(unit, "/s")
+}
+
+// FormatValue formats measurement in unit with four significant digits,
+// durations for ns/op and SI prefixes for other units.
+func FormatValue(v float64, unit string) string {
+	if unit == "ns/op" {
+		for _, u := range []struct {
+			scale  float64
+			suffix string
+		}{{float64(time.Second), "s"}, {float64(time.Millisecond), "ms"}, {float64(time.Microsecond), "µs"}} {
+			if math.Abs(v) >= u.scale {
+				return fmt.Sprintf("%.4g%s", v/u.scale, u.suffix)
+			}
+		}
+		return fmt.Sprintf("%.4gns", v)
+	}
+	for _, u := range []struct {
+		scale  float64
+		prefix string
+	}{{1e9, "G"}, {1e6, "M"}, {1e3, "k"}} {
+		if math.Abs(v) >= u.scale {
+			return fmt.Sprintf("%.4g%s", v/u.scale, u.prefix)
+		}
+	}
+	return fmt.Sprintf("%.4g", v)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bench
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func parseSet(t *testing.T, text string) *Set {
+	t.Helper()
+	s := NewSet()
+	if err := s.Parse(strings.NewReader(text)); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	old := parseSet(t, `pkg: example.com/p
+BenchmarkA-8 100 100 ns/op 64 B/op
+BenchmarkA-8 100 101 ns/op 64 B/op
+BenchmarkA-8 100 102 ns/op 64 B/op
+BenchmarkA-8 100 103 ns/op 64 B/op
+BenchmarkA-8 100 104 ns/op 64 B/op
+BenchmarkB-8 100 400 ns/op 0 B/op
+BenchmarkGone-8 100 1 ns/op 0 B/op
+`)
+	new := parseSet(t, `pkg: example.com/p
+BenchmarkA-8 100 80 ns/op 64 B/op
+BenchmarkA-8 100 81 ns/op 64 B/op
+BenchmarkA-8 100 82 ns/op 64 B/op
+BenchmarkA-8 100 83 ns/op 64 B/op
+BenchmarkA-8 100 84 ns/op 64 B/op
+BenchmarkB-8 100 410 ns/op 0 B/op
+BenchmarkNew-8 100 5 ns/op
+`)
+	tables := Compare(old, new)
+	if len(tables) != 2 || tables[0].Unit != "ns/op" || tables[1].Unit != "B/op" {
+		t.Fatalf("tables = %+v, want ns/op and B/op", tables)
+	}
+	rows := tables[0].Rows
+	if len(rows) != 4 {
+		t.Fatalf("ns/op rows = %+v, want 4", rows)
+	}
+	a := rows[0]
+	if a.Key.Name != "BenchmarkA-8" || !a.Significant() || math.Abs(a.Delta-(82.0/102-1)) > 1e-9 {
+		t.Errorf("A = %+v, want significant -19.6%%", a)
+	}
+	if b := rows[1]; b.Significant() || math.Abs(b.Delta-0.025) > 1e-9 {
+		t.Errorf("B = %+v, want insignificant +2.5%%", b)
+	}
+	if gone, added := rows[2], rows[3]; gone.Comparable() || gone.New.N != 0 || added.Comparable() || added.Old.N != 0 {
+		t.Errorf("Gone, New = %+v, %+v, want not comparable", gone, added)
+	}
+	// geometric mean of A and B medians
+	want := math.Sqrt(82*410)/math.Sqrt(102*400) - 1
+	if got := tables[0].GeomeanDelta(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("GeomeanDelta = %v, want %v", got, want)
+	}
+	// zero medians are left out of geometric mean
+	if got := tables[1].GeomeanDelta(); got != 0 {
+		t.Errorf("B/op GeomeanDelta = %v, want 0", got)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		v    float64
+		unit string
+		want string
+	}{
+		{612.5, "ns/op", "612.5ns"},
+		{10500, "ns/op", "10.5µs"},
+		{1250000, "ns/op", "1.25ms"},
+		{2.5e9, "ns/op", "2.5s"},
+		{1024, "B/op", "1.024k"},
+		{12, "allocs/op", "12"},
+		{104.86, "MB/s", "104.9"},
+	}
+	for _, tt := range tests {
+		if got := FormatValue(tt.v, tt.unit); got != tt.want {
+			t.Errorf("FormatValue(%v, %s) = %q, want %q", tt.v, tt.unit, got, tt.want)
+		}
+	}
+	if LowerIsBetter("MB/s") || !LowerIsBetter("ns/op") {
+		t.Error("LowerIsBetter: rates must be better when higher")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bench
+
+import (
+	"math"
+	"slices"
+)
+
+// Alpha is significance level, differences with greater p-value are not reported.
+const Alpha = 0.05
+
+// maxExact is the largest sample size for which exact distribution of U statistic is used.
+const maxExact = 20
+
+// Median returns median of values, 0 if there are none.
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// Spread returns the largest deviation of values from median relative to median.
+func Spread(values []float64) float64 {
+	median := Median(values)
+	if median == 0 {
+		return 0
+	}
+	spread := 0.0
+	for _, v := range values {
+		spread = max(spread, math.Abs(v-median)/median)
+	}
+	return spread
+}
+
+// MannWhitneyU returns two-sided p-value of Mann-Whitney U test, the probability
+// of samples x and y being at least as different if they came from the same
+// distribution. Exact distribution is used for small samples without ties,
+// normal approximation otherwise.
+func MannWhitneyU(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	type sample struct {
+		v     float64
+		fromX bool
+	}
+	merged := make([]sample, 0, n1+n2)
+	for _, v := range x {
+		merged = append(merged, sample{v, true})
+	}
+	for _, v := range y {
+		merged = append(merged, sample{v, false})
+	}
+	slices.SortFunc(merged, func(a, b sample) int {
+		switch {
+		case a.v < b.v:
+			return -1
+		case a.v > b.v:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	// rank sum of x, tied values get average rank
+	rankSum, ties := 0.0, 0.0
+	for i := 0; i < len(merged); {
+		j := i
+		for j < len(merged) && merged[j].v == merged[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for _, s := range merged[i:j] {
+			if s.fromX {
+				rankSum += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties += t*t*t - t
+		}
+		i = j
+	}
+	u := rankSum - float64(n1*(n1+1))/2
+
+	if ties == 0 && n1 <= maxExact && n2 <= maxExact {
+		return exactP(u, n1, n2)
+	}
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	sigma := math.Sqrt(float64(n1*n2) / 12 * (n + 1 - ties/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	// continuity correction
+	z := max(math.Abs(u-mean)-0.5, 0) / sigma
+	return min(math.Erfc(z/math.Sqrt2), 1)
+}
+
+// exactP returns two-sided p-value of U statistic for samples of sizes n1 and n2.
+func exactP(u float64, n1, n2 int) float64 {
+	counts := uCounts(n1, n2)
+	total, below, above := 0.0, 0.0, 0.0
+	for k, c := range counts {
+		total += c
+		if float64(k) <= u {
+			below += c
+		}
+		if float64(k) >= u {
+			above += c
+		}
+	}
+	return min(2*min(below, above)/total, 1)
+}
+
+// uCounts returns number of orderings of samples of sizes n1 and n2 for each value of U.
+// Largest value either belongs to the first sample and exceeds all n2 values
+// of the second one, or belongs to the second sample and exceeds none.
+func uCounts(n1, n2 int) []float64 {
+	// counts[i][j] is distribution for sizes i and j
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			c := make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				c[0] = 1
+			} else {
+				for k := range c {
+					if k >= j && k-j < len(counts[i-1][j]) {
+						c[k] += counts[i-1][j][k-j]
+					}
+					if k < len(counts[i][j-1]) {
+						c[k] += counts[i][j-1][k]
+					}
+				}
+			}
+			counts[i][j] = c
+		}
+	}
+	return counts[n1][n2]
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+	for _, tt := range tests {
+		if got := Median(tt.values); got != tt.want {
+			t.Errorf("Median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+	if got := Spread([]float64{90, 100, 105}); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("Spread = %v, want 0.1", got)
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		// 2 of 20 orderings are as extreme
+		{"separated 3+3", []float64{1, 2, 3}, []float64{4, 5, 6}, 0.1},
+		// 2 of 252 orderings
+		{"separated 5+5", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{"reversed 5+5", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.0 / 252},
+		{"interleaved", []float64{1, 3, 5}, []float64{2, 4, 6}, 0.7},
+		{"identical", []float64{5, 5, 5}, []float64{5, 5, 5}, 1},
+		{"single", []float64{1}, []float64{2}, 1},
+		{"empty", nil, []float64{2}, 1},
+	}
+	for _, tt := range tests {
+		if got := MannWhitneyU(tt.x, tt.y); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: p = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// ties use normal approximation
+	x := []float64{10, 10, 11, 11, 12, 12, 13, 13}
+	y := []float64{20, 20, 21, 21, 22, 22, 23, 23}
+	if got := MannWhitneyU(x, y); got >= Alpha {
+		t.Errorf("separated with ties: p = %v, want < %v", got, Alpha)
+	}
+	if got := MannWhitneyU(x, x); got != 1 {
+		t.Errorf("equal with ties: p = %v, want 1", got)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bisect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// SkipExitCode is exit code of command when version can not be tested, as with git bisect.
+const SkipExitCode = 125
+
+var (
+	ErrInvalidRange = errors.New("good version must be older than bad version")
+	ErrInconsistent = errors.New("inconsistent results")
+)
+
+// Verdict is a result of command run with a version.
+type Verdict string
+
+const (
+	Good Verdict = "good"
+	Bad  Verdict = "bad"
+	Skip Verdict = "skip"
+)
+
+// Step is a recorded run of command.
+type Step struct {
+	// Version with "go" prefix
+	Version  string    `json:"version"`
+	Verdict  Verdict   `json:"verdict"`
+	ExitCode int       `json:"exit_code"`
+	At       time.Time `json:"at"`
+}
+
+// Session is a state of bisect, saved after each step so it can be resumed.
+type Session struct {
+	// Command is run with each tested version
+	Command []string `json:"command"`
+	// Dir is working directory of command
+	Dir string `json:"dir"`
+	// Versions are releases from known good to known bad version, oldest first
+	Versions []string `json:"versions"`
+	// SkipCodes are exit codes marking version as untestable
+	SkipCodes []int  `json:"skip_codes"`
+	Steps     []Step `json:"steps"`
+}
+
+// New starts bisect between good and bad versions over releases, which may
+// be in any order. Pre-releases are ignored, good and bad are always included.
+func New(releases []string, good, bad string, command []string, dir string) (*Session, error) {
+	good = "go" + strings.TrimPrefix(good, "go")
+	bad = "go" + strings.TrimPrefix(bad, "go")
+	for _, v := range []string{good, bad} {
+		if !gover.IsValid(v) {
+			return nil, fmt.Errorf("%w: %q", gover.ErrInvalid, v)
+		}
+	}
+	if gover.Compare(good, bad) >= 0 {
+		return nil, fmt.Errorf("%w: %s, %s", ErrInvalidRange, good, bad)
+	}
+
+	versions := []string{good, bad}
+	for _, r := range releases {
+		v, err := gover.Parse(r)
+		if err != nil || !v.IsRelease() || gover.Compare(r, good) <= 0 || gover.Compare(r, bad) >= 0 ||
+			slices.Contains(versions, v.String()) {
+			continue
+		}
+		versions = append(versions, v.String())
+	}
+	slices.SortFunc(versions, gover.Compare)
+	return &Session{
+		Command:   command,
+		Dir:       dir,
+		Versions:  versions,
+		SkipCodes: []int{SkipExitCode},
+	}, nil
+}
+
+// Load reads session saved in file, it returns nil session if there is none.
+func Load(file string) (*Session, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read bisect session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decode bisect session %s: %w", file, err)
+	}
+	if len(s.Versions) < 2 || len(s.Command) == 0 {
+		return nil, fmt.Errorf("decode bisect session %s: no versions or command", file)
+	}
+	return &s, nil
+}
+
+// Save writes session to file, replacing it atomically.
+func (s *Session) Save(file string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode bisect session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("save bisect session: %w", err)
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("save bisect session: %w", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return fmt.Errorf("save bisect session: %w", err)
+	}
+	return nil
+}
+
+// Good returns known good version.
+func (s *Session) Good() string {
+	return s.Versions[0]
+}
+
+// Bad returns known bad version.
+func (s *Session) Bad() string {
+	return s.Versions[len(s.Versions)-1]
+}
+
+// VerdictFor returns verdict for exit code of command. Commands exiting with
+// code above 127 (e.g. killed by signal) or not started abort bisect, ok is false then.
+func (s *Session) VerdictFor(exitCode int) (v Verdict, ok bool) {
+	switch {
+	case exitCode == 0:
+		return Good, true
+	case slices.Contains(s.SkipCodes, exitCode):
+		return Skip, true
+	case exitCode > 0 && exitCode < 128:
+		return Bad, true
+	default:
+		return "", false
+	}
+}
+
+// Record adds result of command run with version.
+func (s *Session) Record(version string, v Verdict, exitCode int) {
+	s.Steps = append(s.Steps, Step{Version: version, Verdict: v, ExitCode: exitCode, At: time.Now().UTC()})
+}
+
+// Next returns version to test next, empty when bisect is finished.
+func (s *Session) Next() (string, error) {
+	candidates, err := s.candidates()
+	if err != nil || len(candidates) == 0 {
+		return "", err
+	}
+	return candidates[len(candidates)/2], nil
+}
+
+// FirstBad returns the first bad version once bisect is finished. Versions skipped
+// right before it are returned as well, any of them may be the first bad one.
+func (s *Session) FirstBad() ([]string, error) {
+	lo, hi, err := s.bounds()
+	if err != nil {
+		return nil, err
+	}
+	return s.Versions[lo+1 : hi+1], nil
+}
+
+// Remaining returns number of versions left to test, skipped ones excluded.
+func (s *Session) Remaining() int {
+	candidates, _ := s.candidates()
+	return len(candidates)
+}
+
+// candidates returns untested versions between the newest good and the oldest bad version.
+func (s *Session) candidates() ([]string, error) {
+	lo, hi, err := s.bounds()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []string
+	for _, v := range s.Versions[lo+1 : hi] {
+		if s.verdict(v) == "" {
+			candidates = append(candidates, v)
+		}
+	}
+	return candidates, nil
+}
+
+// bounds returns indices of the newest good and the oldest bad version.
+func (s *Session) bounds() (int, int, error) {
+	lo, hi := 0, len(s.Versions)-1
+	for i, v := range s.Versions {
+		switch s.verdict(v) {
+		case Good:
+			lo = max(lo, i)
+		case Bad:
+			hi = min(hi, i)
+		}
+	}
+	if lo >= hi {
+		return 0, 0, fmt.Errorf("%w: %s is good, but older %s is bad", ErrInconsistent, s.Versions[lo], s.Versions[hi])
+	}
+	return lo, hi, nil
+}
+
+// verdict returns the latest verdict for version, empty if it was not tested.
+func (s *Session) verdict(version string) Verdict {
+	for i := len(s.Steps) - 1; i >= 0; i-- {
+		if s.Steps[i].Version == version {
+			return s.Steps[i].Verdict
+		}
+	}
+	return ""
+}
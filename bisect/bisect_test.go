@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package bisect
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+var releases = []string{
+	"go1.22.3", "go1.22.2", "go1.22.1", "go1.22.0", "go1.22rc1",
+	"go1.21.9", "go1.21.8", "go1.21.1", "go1.21.0", "go1.20.14",
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(releases, "1.21.0", "go1.22.1", []string{"./repro.sh"}, "/src")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	want := []string{"go1.21.0", "go1.21.1", "go1.21.8", "go1.21.9", "go1.22.0", "go1.22.1"}
+	if !slices.Equal(s.Versions, want) {
+		t.Errorf("Versions = %v, want %v", s.Versions, want)
+	}
+	if s.Good() != "go1.21.0" || s.Bad() != "go1.22.1" {
+		t.Errorf("Good, Bad = %s, %s", s.Good(), s.Bad())
+	}
+	if _, err := New(releases, "1.22.1", "1.21.0", nil, ""); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("reversed range: err = %v, want ErrInvalidRange", err)
+	}
+	if _, err := New(releases, "newest", "1.21.0", nil, ""); err == nil {
+		t.Error("invalid version: err = nil")
+	}
+}
+
+// bisectWith runs session to the end with verdicts of versions.
+func bisectWith(t *testing.T, s *Session, verdicts map[string]Verdict) []string {
+	t.Helper()
+	var tested []string
+	for {
+		next, err := s.Next()
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if next == "" {
+			break
+		}
+		if slices.Contains(tested, next) {
+			t.Fatalf("%s tested twice", next)
+		}
+		tested = append(tested, next)
+		s.Record(next, verdicts[next], 0)
+	}
+	return tested
+}
+
+func TestBisect(t *testing.T) {
+	s, err := New(releases, "1.20.14", "1.22.3", []string{"./repro.sh"}, "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	verdicts := map[string]Verdict{
+		"go1.21.0": Good, "go1.21.1": Good, "go1.21.8": Good,
+		"go1.21.9": Bad, "go1.22.0": Bad, "go1.22.1": Bad, "go1.22.2": Bad,
+	}
+	tested := bisectWith(t, s, verdicts)
+	if len(tested) > 3 {
+		t.Errorf("tested %v, want at most 3 steps for 7 versions", tested)
+	}
+	if got, err := s.FirstBad(); err != nil || !slices.Equal(got, []string{"go1.21.9"}) {
+		t.Errorf("FirstBad = %v, %v, want [go1.21.9]", got, err)
+	}
+}
+
+func TestBisectSkip(t *testing.T) {
+	s, err := New(releases, "1.21.0", "1.22.0", []string{"./repro.sh"}, "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	verdicts := map[string]Verdict{"go1.21.1": Good, "go1.21.8": Skip, "go1.21.9": Bad}
+	bisectWith(t, s, verdicts)
+	if got, err := s.FirstBad(); err != nil || !slices.Equal(got, []string{"go1.21.8", "go1.21.9"}) {
+		t.Errorf("FirstBad = %v, %v, want [go1.21.8 go1.21.9]", got, err)
+	}
+	if s.Remaining() != 0 {
+		t.Errorf("Remaining = %d, want 0", s.Remaining())
+	}
+}
+
+func TestBisectInconsistent(t *testing.T) {
+	s, err := New(releases, "1.21.0", "1.22.0", []string{"./repro.sh"}, "")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	s.Record("go1.21.1", Bad, 1)
+	s.Record("go1.21.8", Good, 0)
+	if _, err := s.Next(); !errors.Is(err, ErrInconsistent) {
+		t.Errorf("Next: err = %v, want ErrInconsistent", err)
+	}
+}
+
+func TestVerdictFor(t *testing.T) {
+	s := &Session{SkipCodes: []int{SkipExitCode, 77}}
+	tests := []struct {
+		code int
+		want Verdict
+		ok   bool
+	}{
+		{0, Good, true},
+		{1, Bad, true},
+		{125, Skip, true},
+		{77, Skip, true},
+		{127, Bad, true},
+		{130, "", false},
+		{-1, "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := s.VerdictFor(tt.code); got != tt.want || ok != tt.ok {
+			t.Errorf("VerdictFor(%d) = %q, %v, want %q, %v", tt.code, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "gm", "bisect.json")
+	if s, err := Load(file); err != nil || s != nil {
+		t.Fatalf("Load (missing) = %v, %v, want nil session", s, err)
+	}
+	s, err := New(releases, "1.21.0", "1.22.0", []string{"go", "test", "./..."}, "/src")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	s.Record("go1.21.8", Skip, SkipExitCode)
+	if err := s.Save(file); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !slices.Equal(got.Command, s.Command) || got.Dir != s.Dir || !slices.Equal(got.Versions, s.Versions) ||
+		len(got.Steps) != 1 || got.Steps[0].Verdict != Skip || got.Steps[0].ExitCode != SkipExitCode {
+		t.Errorf("Load = %+v, want %+v", got, s)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// adoptCmd represents the adopt command
+var adoptCmd = &cobra.Command{
+	Use:   "adopt <path>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Register Go toolchain installed outside of gm",
+	Long: `Register Go toolchain installed outside of gm (e.g. /usr/local/go)
+under the version recorded in its VERSION file.
+Use 'gm link' to register toolchain under custom name.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		version, err := toolchain.ReadVersion(path)
+		if err != nil {
+			printError("Failed to read version of toolchain: %s", err)
+			os.Exit(1)
+		}
+		if version == "" {
+			printError("Toolchain at %s has no VERSION file, use 'gm link <name> %s' to register it", path, path)
+			os.Exit(1)
+		}
+		registerExternal(version, path)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(adoptCmd)
+}
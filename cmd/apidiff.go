@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/api"
+	"github.com/x-dvr/gm/sys"
+)
+
+var apidiffPkgs []string
+
+// apidiffCmd represents the apidiff command
+var apidiffCmd = &cobra.Command{
+	Use:   "apidiff <old> <new>",
+	Args:  cobra.ExactArgs(2),
+	Short: "List standard library APIs added between two Go versions",
+	Long: `List exported standard library APIs added by releases after old version
+up to new version, grouped by package. Only minor lines of versions matter,
+'gm apidiff 1.21 1.23' lists APIs added by Go 1.22 and 1.23.
+
+API files ($GOROOT/api/go1.N.txt) are read from installed toolchains. Files
+missing there, e.g. of versions not installed or installed through GOPROXY,
+are fetched from Go source repository and cached in ~/.gm/api.
+
+Use --pkg to show some packages only, "net/..." selects net and its subpackages.
+
+Example usage:
+gm apidiff 1.21 1.23 --pkg net/http`,
+	Run: func(cmd *cobra.Command, args []string) {
+		installed, err := sys.ListInstalledVersions()
+		if err != nil {
+			printError("Failed to list installed versions: %s", err)
+			os.Exit(1)
+		}
+		goRoots := make([]string, 0, len(installed))
+		for _, tc := range installed {
+			goRoots = append(goRoots, tc.Path)
+		}
+		cacheDir, err := sys.APICachePath()
+		if err != nil {
+			printError("Failed to determine API cache path: %s", err)
+			os.Exit(1)
+		}
+		features, err := api.NewSource(goRoots, cacheDir, api.DefaultRepo).Added(args[0], args[1])
+		if err != nil {
+			printError("Failed to read API files: %s", err)
+			os.Exit(1)
+		}
+
+		var lines []string
+		pkg, pkgs, count := "", 0, 0
+		for _, f := range features {
+			if !api.Match(f.Pkg, apidiffPkgs) {
+				continue
+			}
+			if f.Pkg != pkg {
+				if pkg != "" {
+					lines = append(lines, "")
+				}
+				pkg = f.Pkg
+				pkgs++
+				lines = append(lines, sGroupTitle.Render(pkg))
+			}
+			count++
+			line := sSubtext.Render(fmt.Sprintf("%-6s", strings.TrimPrefix(f.Version, "go"))) + " " + sText.Render(f.Decl)
+			if platforms := f.Platforms(); len(platforms) > 0 {
+				line += sSubtext.Render(" (" + strings.Join(platforms, ", ") + ")")
+			}
+			lines = append(lines, line)
+		}
+		if count == 0 {
+			lines = append(lines, sSubtext.Render("no APIs added"))
+		}
+		fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+		if count > 0 {
+			fmt.Println(sInfo.Render(fmt.Sprintf("%d API(s) added in %d package(s)", count, pkgs)))
+		}
+	},
+}
+
+func init() {
+	apidiffCmd.Flags().StringSliceVar(&apidiffPkgs, "pkg", nil, "Show only packages matching patterns, e.g. net/http or net/...")
+	rootCmd.AddCommand(apidiffCmd)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+	"github.com/x-dvr/gm/vuln"
+)
+
+var auditDB string
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Args:  cobra.ExactArgs(0),
+	Short: "Report known vulnerabilities of installed versions of Go toolchain",
+	Long: fmt.Sprintf(`Check installed toolchains against Go vulnerability database for
+standard library and toolchain advisories affecting their exact versions.
+Exit code is 1 if any installed version is affected.
+
+Database is selected with --db, GOVULNDB environment variable or "vulndb"
+key of config, %s is used by default. Use file:// URL for offline copy.
+Data fetched from the database is cached in ~/.gm/vulndb.`, vuln.DefaultDB),
+	Run: func(cmd *cobra.Command, args []string) {
+		installed, err := sys.ListInstalledVersions()
+		if err != nil {
+			printError("Failed to list installed versions: %s", err)
+			os.Exit(1)
+		}
+		if len(installed) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("No Go versions found")))
+			return
+		}
+		current, err := sys.GetCurrentVersion()
+		if err != nil {
+			printError("Failed to determine current version: %s", err)
+			os.Exit(1)
+		}
+		client, err := vulnClient(false)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+
+		versions := make([]string, 0, len(installed))
+		for _, tc := range installed {
+			versions = append(versions, exactVersion(tc))
+		}
+		var advisories map[string][]vuln.Advisory
+		tui := pbar.New(fmt.Sprintf("Auditing %d toolchain(s)", len(installed)))
+		go func() {
+			tui.SetInfo("Checking Go vulnerability database ...")
+			advisories, err = client.Check(versions)
+			tui.Exit(err)
+		}()
+		if err := tui.Run(); err != nil {
+			os.Exit(1)
+		}
+
+		affected := false
+		items := make([]string, 0, len(installed))
+		for i, tc := range installed {
+			label := tc.Version
+			if current != nil && current.Version == tc.Version {
+				label += " - current"
+			}
+			advs := advisories[versions[i]]
+			if len(advs) == 0 {
+				items = append(items, sListItem.Render(sText.Render(label+" - no known vulnerabilities")))
+				continue
+			}
+			affected = true
+			lines := []string{sErrorText.Render(fmt.Sprintf("%s - %d known vulnerabilities", label, len(advs)))}
+			for _, adv := range advs {
+				lines = append(lines, sText.Render(advisoryLine(adv)))
+				if adv.Summary != "" {
+					lines = append(lines, sSubtext.Render("  "+adv.Summary))
+				}
+			}
+			items = append(items, sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+		}
+		fmt.Println(sPadLeft.Render(lipgloss.JoinVertical(lipgloss.Left, items...)))
+		if affected {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditDB, "db", "", "URL of Go vulnerability database")
+	rootCmd.AddCommand(auditCmd)
+}
+
+// vulnClient returns client of vulnerability database selected by flag, environment or config.
+// Offline client uses only data cached by previous audits.
+func vulnClient(offline bool) (*vuln.Client, error) {
+	db := auditDB
+	if db == "" {
+		db = os.Getenv("GOVULNDB")
+	}
+	if db == "" {
+		cfg, err := loadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("load config: %w", err)
+		}
+		db = cfg.VulnDB
+	}
+	if db == "" {
+		db = vuln.DefaultDB
+	}
+	cacheDir, err := sys.VulnCachePath()
+	if err != nil {
+		return nil, fmt.Errorf("determine vulnerability database cache path: %w", err)
+	}
+	return vuln.NewClient(db, cacheDir, offline)
+}
+
+// exactVersion returns Go version of toolchain as recorded in its tree.
+func exactVersion(tc sys.Toolchain) string {
+	if tc.Record != nil && tc.Record.Version != "" {
+		return tc.Record.Version
+	}
+	if v, err := toolchain.ReadVersion(tc.Path); err == nil && v != "" {
+		return v
+	}
+	return "go" + tc.Version
+}
+
+// advisoryLine describes advisory in a single line.
+func advisoryLine(adv vuln.Advisory) string {
+	line := adv.ID
+	if len(adv.Aliases) > 0 {
+		line += " (" + strings.Join(adv.Aliases, ", ") + ")"
+	}
+	if adv.Module == vuln.ToolchainModule {
+		line += " in toolchain"
+	} else if len(adv.Packages) > 0 {
+		line += " in " + strings.Join(adv.Packages, ", ")
+	}
+	if adv.Fixed != "" {
+		return line + ", fixed in " + strings.TrimPrefix(adv.Fixed, "go")
+	}
+	return line + ", no fix available"
+}
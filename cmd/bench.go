@@ -0,0 +1,296 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/bench"
+	"github.com/x-dvr/gm/matrix"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+var (
+	benchOld     string
+	benchNew     string
+	benchPattern string
+	benchCount   int
+	benchTime    string
+	benchCompare bool
+)
+
+// benchSide is one of compared toolchains.
+type benchSide struct {
+	name string
+	env  []string
+	// file collects raw output of runs
+	file string
+}
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench --old <version> --new <version> [--] [go test flags] [packages]",
+	Short: "Compare benchmarks of two installed Go versions",
+	Long: `Run 'go test -bench' --count times with each of two installed Go versions
+and print a table of medians, their changes and p-values of Mann-Whitney U
+test, like benchstat does. Changes with p-value of 0.05 or more are shown
+as "~". Runs with both versions are interleaved to reduce noise of system
+load changing over time.
+
+Arguments after "--" are passed to 'go test', e.g. packages and -benchmem.
+Raw output is saved to ~/.gm/bench/<time>/<version>.txt, usable by benchstat
+as well. Compare saved results again with --compare.
+
+Example usage:
+gm bench --old 1.22.5 --new 1.23.1 -- -benchmem ./...
+gm bench --compare ~/.gm/bench/20250101-120000/go1.22.5.txt ~/.gm/bench/20250101-120000/go1.23.1.txt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if benchCompare {
+			if len(args) != 2 {
+				printError("Two files with saved results are required, see 'gm bench --help'")
+				os.Exit(1)
+			}
+			var sets [2]*bench.Set
+			for i, file := range args {
+				s, err := bench.ParseFile(file)
+				if err != nil {
+					printError("Failed to read results: %s", err)
+					os.Exit(1)
+				}
+				sets[i] = s
+			}
+			oldName := strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+			newName := strings.TrimSuffix(filepath.Base(args[1]), filepath.Ext(args[1]))
+			fmt.Println(benchReport(bench.Compare(sets[0], sets[1]), oldName, newName))
+			return
+		}
+
+		if benchOld == "" || benchNew == "" {
+			printError("Old and new versions are required, see 'gm bench --help'")
+			os.Exit(1)
+		}
+		if benchCount < 1 {
+			printError("Count must be positive")
+			os.Exit(1)
+		}
+		dir, err := sys.BenchPath()
+		if err != nil {
+			printError("Failed to determine path of results: %s", err)
+			os.Exit(1)
+		}
+		dir = filepath.Join(dir, time.Now().Format("20060102-150405"))
+
+		var sides [2]benchSide
+		for i, version := range []string{benchOld, benchNew} {
+			version = "go" + strings.TrimPrefix(version, "go")
+			sides[i] = benchToolchain(version, dir)
+		}
+		if sides[0].name == sides[1].name {
+			printError("Old and new versions must differ")
+			os.Exit(1)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			printError("Failed to create directory of results: %s", err)
+			os.Exit(1)
+		}
+
+		goTest := []string{"go", "test", "-run", "^$", "-bench", benchPattern, "-count", "1"}
+		if benchTime != "" {
+			goTest = append(goTest, "-benchtime", benchTime)
+		}
+		runner := &matrix.Runner{Args: append(goTest, args...)}
+		results := make(chan [2]*bench.Set, 1)
+		tui := pbar.New(fmt.Sprintf("Benchmarking Go %s against Go %s", sides[1].name, sides[0].name))
+
+		go func() {
+			tracker := tui.GetTracker()
+			sets := [2]*bench.Set{bench.NewSet(), bench.NewSet()}
+			for i := range benchCount {
+				// order alternates, so drift of system load affects both versions alike
+				order := []int{0, 1}
+				if i%2 == 1 {
+					order = []int{1, 0}
+				}
+				for _, k := range order {
+					side := sides[k]
+					tracker.Reset(fmt.Sprintf("Run %d of %d with Go %s ...", i+1, benchCount, side.name))
+					res := runner.Run(context.Background(), []matrix.Job{{Name: side.name, Env: side.env}})[0]
+					if err := appendFile(side.file, res.Output); err != nil {
+						tui.Exit(fmt.Errorf("save results: %w", err))
+						return
+					}
+					if !res.Passed() {
+						tui.Exit(fmt.Errorf("go test with Go %s: %w, output is saved in %s", side.name, res.Err, side.file))
+						return
+					}
+					if err := sets[k].Parse(bytes.NewReader(res.Output)); err != nil {
+						tui.Exit(err)
+						return
+					}
+				}
+			}
+			if len(sets[0].Keys) == 0 && len(sets[1].Keys) == 0 {
+				tui.Exit(errors.New("no benchmarks were run, check --bench pattern and packages"))
+				return
+			}
+			results <- sets
+			tui.SetInfo(fmt.Sprintf("Completed %d run(s) with each version", benchCount))
+			tui.Exit(nil)
+		}()
+
+		if err := tui.Run(); err != nil {
+			os.Exit(1)
+		}
+		select {
+		case sets := <-results:
+			fmt.Println(benchReport(bench.Compare(sets[0], sets[1]), sides[0].name, sides[1].name))
+			fmt.Println(sInfo.Render(fmt.Sprintf("Raw results are saved in %s, compare them again with\ngm bench --compare %s %s",
+				dir, sides[0].file, sides[1].file)))
+		default:
+			// interrupted
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchOld, "old", "", "Installed version to compare against")
+	benchCmd.Flags().StringVar(&benchNew, "new", "", "Installed version to compare")
+	benchCmd.Flags().StringVar(&benchPattern, "bench", ".", "Run benchmarks matching regular expression")
+	benchCmd.Flags().IntVar(&benchCount, "count", 10, "Number of runs with each version")
+	benchCmd.Flags().StringVar(&benchTime, "benchtime", "", "Run enough iterations of each benchmark to take t, passed to go test")
+	benchCmd.Flags().BoolVar(&benchCompare, "compare", false, "Compare results saved in two files instead of running benchmarks")
+	benchCmd.MarkFlagsMutuallyExclusive("compare", "old")
+	benchCmd.MarkFlagsMutuallyExclusive("compare", "new")
+	// flags after "--" belong to go test
+	benchCmd.Flags().SetInterspersed(false)
+	addIgnorePolicyFlag(benchCmd)
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchToolchain returns environment of installed version, exiting if it can not be used.
+func benchToolchain(version, dir string) benchSide {
+	name := strings.TrimPrefix(version, "go")
+	if err := enforcePolicy("bench", version, true); err != nil {
+		printError("%s", err)
+		os.Exit(1)
+	}
+	goRoot, err := sys.GoRoot(version)
+	if errors.Is(err, sys.ErrNotInstalled) {
+		printError("Go %s is not installed, install it with 'gm install %s'", name, name)
+		os.Exit(1)
+	}
+	if err != nil {
+		printError("Failed to determine GOROOT: %s", err)
+		os.Exit(1)
+	}
+	env, err := sys.IsolatedEnv(goRoot, os.Environ())
+	if err != nil {
+		printError("Failed to prepare environment: %s", err)
+		os.Exit(1)
+	}
+	return benchSide{name: name, env: env, file: filepath.Join(dir, version+".txt")}
+}
+
+// appendFile appends data to file, creating it if needed.
+func appendFile(file string, data []byte) error {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// benchReport renders comparison tables, one per unit.
+func benchReport(tables []bench.Table, oldName, newName string) string {
+	var lines []string
+	for i, t := range tables {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, benchTable(t, oldName, newName)...)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, sSubtext.Render("no benchmark results"))
+	}
+	return sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+}
+
+// benchTable renders comparison of benchmarks in one unit.
+func benchTable(t bench.Table, oldName, newName string) []string {
+	type row struct {
+		pkg   string
+		cells [5]string
+		style lipgloss.Style
+	}
+	value := func(s bench.Summary) string {
+		if s.N == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%s ±%.0f%%", bench.FormatValue(s.Median, t.Unit), s.Spread*100)
+	}
+
+	rows := []row{{cells: [5]string{t.Unit, oldName, newName, "delta", ""}, style: sSubtext}}
+	for _, r := range t.Rows {
+		cells := [5]string{strings.TrimPrefix(r.Key.Name, "Benchmark"), value(r.Old), value(r.New), "", ""}
+		style := sSubtext
+		if r.Comparable() {
+			cells[3] = "~"
+			cells[4] = fmt.Sprintf("(p=%.3f n=%d+%d)", r.P, r.Old.N, r.New.N)
+		}
+		if r.Significant() {
+			cells[3] = fmt.Sprintf("%+.2f%%", r.Delta*100)
+			style = sActiveText
+			if (r.Delta > 0) == bench.LowerIsBetter(t.Unit) {
+				style = sErrorText
+			}
+		}
+		rows = append(rows, row{pkg: r.Key.Pkg, cells: cells, style: style})
+	}
+	if t.OldGeomean != 0 && len(t.Rows) > 1 {
+		rows = append(rows, row{cells: [5]string{"geomean", bench.FormatValue(t.OldGeomean, t.Unit),
+			bench.FormatValue(t.NewGeomean, t.Unit), fmt.Sprintf("%+.2f%%", t.GeomeanDelta()*100), ""}, style: sText})
+	}
+
+	var widths [5]int
+	for _, r := range rows {
+		for i, c := range r.cells {
+			widths[i] = max(widths[i], lipgloss.Width(c))
+		}
+	}
+	var lines []string
+	pkg := ""
+	for i, r := range rows {
+		if i > 0 && r.pkg != "" && r.pkg != pkg {
+			pkg = r.pkg
+			lines = append(lines, sGroupTitle.UnsetMargins().Render("pkg: "+pkg))
+		}
+		cells := make([]string, len(r.cells))
+		for j, c := range r.cells {
+			cells[j] = c + strings.Repeat(" ", widths[j]-lipgloss.Width(c))
+		}
+		// names and values are plain, change is highlighted
+		text := sText.Render(strings.Join(cells[:3], "  ")) + "  " + r.style.Render(strings.Join(cells[3:], "  "))
+		if i == 0 {
+			text = sSubtext.Render(strings.Join(cells, "  "))
+		}
+		lines = append(lines, text)
+	}
+	return lines
+}
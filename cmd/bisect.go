@@ -0,0 +1,246 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/bisect"
+	"github.com/x-dvr/gm/matrix"
+	"github.com/x-dvr/gm/sys"
+)
+
+var (
+	bisectGood      string
+	bisectBad       string
+	bisectSkipCodes []int
+	bisectResume    bool
+	bisectLog       bool
+	bisectReset     bool
+)
+
+// bisectCmd represents the bisect command
+var bisectCmd = &cobra.Command{
+	Use:   "bisect --good <version> --bad <version> [--] <command> [args...]",
+	Short: "Find the first Go release where behaviour of command changes",
+	Long: fmt.Sprintf(`Binary search over Go releases, patch releases included, between known
+good and bad versions for the first one command fails with. Each tested
+version is installed on demand and command runs in environment set like
+'gm env' does but pointing directly at that version.
+
+Command exit code decides result of version, as with git bisect:
+	0         good
+	%-9d skip, version can not be tested (see --skip-exit-code)
+	1-127     bad
+	other     bisect stops, e.g. command was killed
+
+Results are saved after each version, interrupted bisect is continued
+with --resume. Use --log to show results and --reset to discard them.
+
+Example usage:
+gm bisect --good 1.21.0 --bad 1.22.3 -- ./repro.sh`, bisect.SkipExitCode),
+	Run: func(cmd *cobra.Command, args []string) {
+		file, err := sys.BisectPath()
+		if err != nil {
+			printError("Failed to determine bisect state path: %s", err)
+			os.Exit(1)
+		}
+		if bisectReset {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				printError("Failed to remove bisect state: %s", err)
+				os.Exit(1)
+			}
+			fmt.Println(sInfo.Render("Bisect state is removed"))
+			return
+		}
+		saved, err := bisect.Load(file)
+		if err != nil {
+			printError("Failed to load bisect state: %s", err)
+			os.Exit(1)
+		}
+
+		switch {
+		case bisectLog:
+			if saved == nil {
+				printError("No bisect is in progress")
+				os.Exit(1)
+			}
+			fmt.Println(bisectReport(saved))
+			return
+		case bisectResume:
+			if saved == nil {
+				printError("No bisect is in progress")
+				os.Exit(1)
+			}
+			runBisect(saved, file)
+			return
+		}
+
+		if bisectGood == "" || bisectBad == "" || len(args) == 0 {
+			printError("Known good and bad versions and command are required, see 'gm bisect --help'")
+			os.Exit(1)
+		}
+		// results of finished bisect are replaced
+		if saved != nil && saved.Remaining() > 0 {
+			printError("Bisect is in progress, continue it with --resume or discard it with --reset")
+			os.Exit(1)
+		}
+		catalog, err := loadCatalog()
+		if err != nil {
+			printError("Failed to load release catalog: %s", err)
+			os.Exit(1)
+		}
+		releases := make([]string, 0, len(catalog.Releases))
+		for _, r := range catalog.Releases {
+			releases = append(releases, r.Version)
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			printError("Failed to determine working directory: %s", err)
+			os.Exit(1)
+		}
+		s, err := bisect.New(releases, bisectGood, bisectBad, args, dir)
+		if err != nil {
+			printError("Failed to start bisect: %s", err)
+			os.Exit(1)
+		}
+		s.SkipCodes = bisectSkipCodes
+		runBisect(s, file)
+	},
+}
+
+func init() {
+	bisectCmd.Flags().StringVar(&bisectGood, "good", "", "Version command succeeds with")
+	bisectCmd.Flags().StringVar(&bisectBad, "bad", "", "Version command fails with")
+	bisectCmd.Flags().IntSliceVar(&bisectSkipCodes, "skip-exit-code", []int{bisect.SkipExitCode}, "Exit codes marking version as untestable")
+	bisectCmd.Flags().BoolVar(&bisectResume, "resume", false, "Continue interrupted bisect")
+	bisectCmd.Flags().BoolVar(&bisectLog, "log", false, "Show results of bisect in progress")
+	bisectCmd.Flags().BoolVar(&bisectReset, "reset", false, "Discard bisect in progress")
+	bisectCmd.MarkFlagsMutuallyExclusive("resume", "log", "reset", "good")
+	bisectCmd.MarkFlagsMutuallyExclusive("resume", "log", "reset", "bad")
+	// flags after the command belong to it
+	bisectCmd.Flags().SetInterspersed(false)
+	addIgnorePolicyFlag(bisectCmd)
+	rootCmd.AddCommand(bisectCmd)
+}
+
+// runBisect tests versions until the first bad one is found, saving state after each of them.
+func runBisect(s *bisect.Session, file string) {
+	// interrupted command is killed, bisect is resumed later
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	stopped := func(format string, args ...any) {
+		printError(format+", continue with 'gm bisect --resume'", args...)
+		os.Exit(1)
+	}
+	if err := s.Save(file); err != nil {
+		printError("%s", err)
+		os.Exit(1)
+	}
+
+	for {
+		version, err := s.Next()
+		if err != nil {
+			printError("Bisect failed: %s, see 'gm bisect --log'", err)
+			os.Exit(1)
+		}
+		if version == "" {
+			break
+		}
+		name := strings.TrimPrefix(version, "go")
+		left := s.Remaining()
+		fmt.Println(sInfo.Render(fmt.Sprintf("Testing Go %s, %d version(s) left, about %d step(s)", name, left, bits.Len(uint(left)))))
+
+		if err := enforcePolicy("bisect", version, true); err != nil {
+			stopped("%s", err)
+		}
+		goRoot, err := sys.GoRoot(version)
+		if errors.Is(err, sys.ErrNotInstalled) {
+			installMissing([]string{version})
+			goRoot, err = sys.GoRoot(version)
+		}
+		if err != nil {
+			stopped("Failed to find Go %s: %s", name, err)
+		}
+		env, err := sys.IsolatedEnv(goRoot, os.Environ())
+		if err != nil {
+			stopped("Failed to prepare environment: %s", err)
+		}
+
+		runner := &matrix.Runner{Args: s.Command, Dir: s.Dir, Out: os.Stdout}
+		res := runner.Run(ctx, []matrix.Job{{Name: name, Env: env}})[0]
+		if ctx.Err() != nil {
+			stopped("Bisect interrupted")
+		}
+		verdict, ok := s.VerdictFor(res.ExitCode)
+		if !ok {
+			stopped("Command failed with Go %s: %s", name, res.Err)
+		}
+		s.Record(version, verdict, res.ExitCode)
+		if err := s.Save(file); err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		fmt.Println(sPadLeft.Render(bisectStepLine(bisect.Step{Version: version, Verdict: verdict, ExitCode: res.ExitCode})))
+	}
+	fmt.Println(bisectReport(s))
+}
+
+// bisectReport renders results of bisect and the first bad version once it is found.
+func bisectReport(s *bisect.Session) string {
+	lines := []string{
+		sGroupTitle.Render(fmt.Sprintf("Bisect %s..%s: %s", strings.TrimPrefix(s.Good(), "go"),
+			strings.TrimPrefix(s.Bad(), "go"), strings.Join(s.Command, " "))),
+	}
+	for _, step := range s.Steps {
+		lines = append(lines, bisectStepLine(step))
+	}
+	if len(s.Steps) == 0 {
+		lines = append(lines, sSubtext.Render("no versions tested yet"))
+	}
+	lines = append(lines, "")
+
+	next, err := s.Next()
+	switch {
+	case err != nil:
+		lines = append(lines, sErrorText.Render(err.Error()))
+	case next != "":
+		lines = append(lines, sText.Render(fmt.Sprintf("%d version(s) left to test", s.Remaining())))
+	default:
+		firstBad, _ := s.FirstBad()
+		names := make([]string, len(firstBad))
+		for i, v := range firstBad {
+			names[i] = strings.TrimPrefix(v, "go")
+		}
+		if len(names) == 1 {
+			lines = append(lines, sActiveText.Render(fmt.Sprintf("Go %s is the first bad version", names[0])))
+		} else {
+			lines = append(lines, sWarningText.Render(fmt.Sprintf("The first bad version is one of %s, skipped versions could not be tested",
+				strings.Join(names, ", "))))
+		}
+	}
+	return sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+}
+
+// bisectStepLine renders result of tested version.
+func bisectStepLine(step bisect.Step) string {
+	text := fmt.Sprintf("%s - %s (exit %d)", strings.TrimPrefix(step.Version, "go"), step.Verdict, step.ExitCode)
+	switch step.Verdict {
+	case bisect.Good:
+		return sActiveText.Render(text)
+	case bisect.Bad:
+		return sErrorText.Render(text)
+	default:
+		return sWarningText.Render(text)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/proxy"
+	"github.com/x-dvr/gm/sys"
+)
+
+var doctorFix bool
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Args:  cobra.ExactArgs(0),
+	Short: "Check Go setup for common problems",
+	Long: `Check Go setup for common problems and suggest fixes:
+- go command of another installation (/usr/local/go/bin, Homebrew) comes first in PATH
+- GOROOT points outside of gm, e.g. exported by an old shell profile
+- current version points to a removed directory
+- shell profile does not set up environment with 'gm env'
+- GOTOOLCHAIN lets go command switch to toolchains downloaded outside of gm
+- backup of gm binary left by upgrade
+
+Use --fix to apply fixes which are safe to apply automatically: setting the
+newest installed release as current and removing the backup. Changes of PATH,
+shell profiles and go env file are only suggested.
+
+Example usage:
+gm doctor --fix`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles, err := sys.ShellProfiles()
+		if err != nil {
+			printError("Failed to determine shell profiles: %s", err)
+			os.Exit(1)
+		}
+
+		// checks run in order, so later ones see fixes of earlier ones, e.g. repaired current version
+		checks := []func() (sys.Diagnosis, bool){
+			func() (sys.Diagnosis, bool) { return sys.CheckPath(os.Getenv("PATH")), true },
+			func() (sys.Diagnosis, bool) { return sys.CheckGoRoot(os.Getenv("GOROOT"), profiles), true },
+			func() (sys.Diagnosis, bool) { return sys.CheckCurrent(), true },
+			// environment variables are set for the user on Windows, no profile to check
+			func() (sys.Diagnosis, bool) { return sys.CheckProfile(profiles), len(profiles) > 0 },
+			doctorToolchainSwitch,
+			func() (sys.Diagnosis, bool) {
+				exePath, err := os.Executable()
+				return sys.CheckBackup(exePath), err == nil
+			},
+		}
+
+		items := make([]string, 0, len(checks))
+		failed := false
+		for _, check := range checks {
+			d, ok := check()
+			if !ok {
+				continue
+			}
+			fixed := false
+			var fixErr error
+			if doctorFix && d.Status != sys.StatusPass && d.Apply != nil {
+				if fixErr = d.Apply(); fixErr == nil {
+					fixed = true
+				}
+			}
+			if d.Status == sys.StatusFail && !fixed {
+				failed = true
+			}
+			items = append(items, sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, doctorLines(d, fixed, fixErr)...)))
+		}
+		fmt.Println(sPadLeft.Render(lipgloss.JoinVertical(lipgloss.Left, items...)))
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Apply fixes which are safe to apply automatically")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorToolchainSwitch checks GOTOOLCHAIN reported by go command of current version.
+// It is skipped without usable current version, current version check reports that.
+func doctorToolchainSwitch() (sys.Diagnosis, bool) {
+	tc, err := sys.GetCurrentVersion()
+	if err != nil || tc == nil {
+		return sys.Diagnosis{}, false
+	}
+	if _, err := os.Stat(tc.Path); err != nil {
+		return sys.Diagnosis{}, false
+	}
+	env, err := sys.GoEnv(tc.Path, "GOTOOLCHAIN", "GOPROXY")
+	if err != nil {
+		return sys.Diagnosis{Check: "GOTOOLCHAIN", Status: sys.StatusWarn, Message: err.Error()}, true
+	}
+	_, fromEnv := os.LookupEnv("GOTOOLCHAIN")
+	return sys.CheckToolchainSwitch(env["GOTOOLCHAIN"], fromEnv, env["GOPROXY"], proxy.DefaultAddr, tc.Version), true
+}
+
+// doctorLines renders result of check with suggested or applied fix.
+func doctorLines(d sys.Diagnosis, fixed bool, fixErr error) []string {
+	style := sActiveText
+	switch d.Status {
+	case sys.StatusWarn:
+		style = sWarningText
+	case sys.StatusFail:
+		style = sErrorText
+	}
+	lines := []string{style.Render(fmt.Sprintf("[%s] %s", d.Status, d.Check)), sText.Render(d.Message)}
+	switch {
+	case fixed:
+		lines = append(lines, sActiveText.Render("fixed: "+d.Fix))
+	case fixErr != nil:
+		lines = append(lines, sErrorText.Render(fmt.Sprintf("failed to fix: %s", fixErr)))
+	case d.Fix != "" && d.Apply != nil:
+		lines = append(lines, sSubtext.Render("fix: "+d.Fix+" (run 'gm doctor --fix')"))
+	case d.Fix != "":
+		lines = append(lines, sSubtext.Render("fix: "+d.Fix))
+	}
+	return lines
+}
@@ -4,10 +4,20 @@ Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/proxy"
 	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var (
+	envProxyAddr string
+	envHook      bool
+	envProject   bool
 )
 
 // envCmd represents the env command
@@ -16,15 +26,77 @@ var envCmd = &cobra.Command{
 	Short: "Output shell commands to set environment variables",
 	Long: `Example usage:
 eval $(gm env)
+
+Use --proxy to point GOPROXY at toolchain proxy started with "gm proxy serve":
+eval $(gm env --proxy)
+
+Use --hook to switch toolchain to the version pinned by project
+in working directory automatically (bash, zsh and fish):
+eval "$(gm env --hook)"
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := sys.PrepareGoEnvs(); err != nil {
+		if envHook {
+			if err := sys.PrintShellHook(); err != nil {
+				printError("Failed to output shell hook: %s", err)
+				os.Exit(1)
+			}
+			return
+		}
+		if envProject {
+			printProjectEnvs()
+			return
+		}
+		goproxy := ""
+		if envProxyAddr != "" {
+			goproxy = "http://" + envProxyAddr + ",direct"
+		}
+		if err := sys.PrepareGoEnvs(goproxy); err != nil {
 			printError("Failed to prepare env variables: %s", err)
 			os.Exit(1)
 		}
+		// shell startup must not wait for network, only cached catalog is used
+		current, err := sys.GetCurrentVersion()
+		if err != nil || current == nil {
+			return
+		}
+		if file, err := sys.CatalogPath(); err == nil {
+			if catalog, err := toolchain.CachedCatalog(file); err == nil {
+				warnUnsupported(current.Version, catalog)
+			}
+		}
 	},
 }
 
 func init() {
+	envCmd.Flags().StringVar(&envProxyAddr, "proxy", "", "Set GOPROXY to toolchain proxy listening on the given address")
+	envCmd.Flags().Lookup("proxy").NoOptDefVal = proxy.DefaultAddr
+	envCmd.Flags().BoolVar(&envHook, "hook", false, "Output shell hook activating version pinned by project in working directory")
+	envCmd.Flags().BoolVar(&envProject, "project", false, "Output GOROOT and PATH of version pinned by project in working directory")
+	envCmd.MarkFlagsMutuallyExclusive("hook", "project", "proxy")
 	rootCmd.AddCommand(envCmd)
 }
+
+// printProjectEnvs outputs GOROOT and PATH of version pinned by project in working
+// directory, falling back to current version. It runs on each shell prompt.
+func printProjectEnvs() {
+	goRoot, pin, err := projectGoRoot(".")
+	if err != nil && pin != nil {
+		fmt.Fprintln(os.Stderr, sPadLeft.Render(sWarningText.Render(err.Error())))
+		goRoot, err = sys.GoRoot("")
+	}
+	if err != nil {
+		// nothing to activate
+		return
+	}
+	if err := enforcePolicy("env", toolchainVersion(goRoot), false); err != nil {
+		// forbidden toolchain is removed from environment
+		fmt.Fprintln(os.Stderr, sPadLeft.Render(sErrorText.Render(err.Error()+", use 'gm exec --ignore-policy' in emergencies")))
+		goRoot = ""
+	} else {
+		toolchain.TouchLastUsedEvery(goRoot, usageInterval)
+	}
+	if err := sys.PrintToolchainEnvs(goRoot); err != nil {
+		printError("Failed to prepare env variables: %s", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// usageInterval is how often use of toolchain by exec and shell hook is recorded,
+// the hook runs on each prompt and must not rewrite install record every time.
+const usageInterval = time.Hour
+
+// execCmd represents the exec command
+var execCmd = &cobra.Command{
+	Use:   "exec <command> [args...]",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Run command with Go toolchain pinned by project in working directory",
+	Long: `Run command with GOROOT and PATH set to Go toolchain pinned by project
+in working directory or its parents (.go-version, .tool-versions or
+toolchain directive of go.mod). Current version is used when project
+pins no version. Version must be allowed by policy, see 'gm policy'.
+
+Example usage:
+gm exec go test ./...`,
+	Run: func(cmd *cobra.Command, args []string) {
+		goRoot, _, err := projectGoRoot(".")
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		if err := enforcePolicy("exec", toolchainVersion(goRoot), false); err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		// usage tracking is best effort, external toolchains have no record
+		toolchain.TouchLastUsedEvery(goRoot, usageInterval)
+		env, err := sys.ToolchainEnv(goRoot, os.Environ())
+		if err != nil {
+			printError("Failed to prepare environment: %s", err)
+			os.Exit(1)
+		}
+
+		// lookup must use PATH of the selected toolchain
+		for _, kv := range env {
+			if path, ok := strings.CutPrefix(kv, "PATH="); ok {
+				os.Setenv("PATH", path)
+			}
+		}
+		c := exec.Command(args[0], args[1:]...)
+		c.Env = env
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			printError("Failed to run %s: %s", args[0], err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	// flags after the command belong to it
+	execCmd.Flags().SetInterspersed(false)
+	addIgnorePolicyFlag(execCmd)
+	rootCmd.AddCommand(execCmd)
+}
+
+// projectGoRoot returns GOROOT of Go toolchain pinned by project dir belongs to,
+// or GOROOT of current version if project pins no version. Pin is nil in the latter case.
+func projectGoRoot(dir string) (string, *project.Pin, error) {
+	pin, err := project.Find(dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("find pinned version: %w", err)
+	}
+	version := ""
+	if pin != nil {
+		version = pin.Version
+	}
+	goRoot, err := sys.GoRoot(version)
+	if errors.Is(err, sys.ErrNotInstalled) {
+		if pin == nil {
+			return "", nil, errors.New("no version of Go toolchain is set as current")
+		}
+		return "", pin, fmt.Errorf("Go %s pinned by %s is not installed, run 'gm pin %s' in %s",
+			strings.TrimPrefix(pin.Version, "go"), pin.File(), strings.TrimPrefix(pin.Version, "go"), pin.Dir)
+	}
+	if err != nil {
+		return "", pin, fmt.Errorf("determine GOROOT: %w", err)
+	}
+	return goRoot, pin, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var implodeYes bool
+
+// implodeCmd represents the implode command
+var implodeCmd = &cobra.Command{
+	Use:   "implode",
+	Args:  cobra.ExactArgs(0),
+	Short: "Remove gm with all installed versions",
+	Long: `Remove gm block from shell profiles and delete ~/.gm with all installed
+versions, workspace (GOPATH) and caches, after showing their sizes and asking
+for confirmation. Toolchains registered with 'gm link' or 'gm adopt' are kept,
+only links to them are removed.
+
+Example usage:
+gm implode --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		root, err := sys.RootPath()
+		if err != nil {
+			printError("Failed to determine gm directory: %s", err)
+			os.Exit(1)
+		}
+		profiles, err := sys.ProfilesWithBlock()
+		if err != nil {
+			printError("Failed to check shell profiles: %s", err)
+			os.Exit(1)
+		}
+		entries, err := os.ReadDir(root)
+		if err != nil && !os.IsNotExist(err) {
+			printError("Failed to read gm directory: %s", err)
+			os.Exit(1)
+		}
+		if len(entries) == 0 && len(profiles) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("Nothing to remove")))
+			return
+		}
+
+		var lines []string
+		var total int64
+		for _, e := range entries {
+			size, err := toolchain.DiskUsage(filepath.Join(root, e.Name()))
+			if err != nil {
+				printError("Failed to compute disk usage: %s", err)
+				os.Exit(1)
+			}
+			total += size
+			lines = append(lines, sText.Render(fmt.Sprintf("%-14s", e.Name()))+" "+sSubtext.Render(formatSize(size)))
+		}
+		items := []string{}
+		if len(entries) > 0 {
+			title := sGroupTitle.Render(fmt.Sprintf("%s (%s)", root, formatSize(total)))
+			items = append(items, sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, lines...)...)))
+		}
+		if len(profiles) > 0 {
+			title := sGroupTitle.Render("gm block in shell profiles")
+			var files []string
+			for _, f := range profiles {
+				files = append(files, sText.Render(f))
+			}
+			items = append(items, sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, files...)...)))
+		}
+		fmt.Println(sPadLeft.Render(lipgloss.JoinVertical(lipgloss.Left, items...)))
+
+		if !implodeYes && !confirm("Remove gm completely?") {
+			fmt.Println(sInfo.Render("Nothing is removed"))
+			return
+		}
+		for _, f := range profiles {
+			if _, err := sys.RemoveProfileBlock(f); err != nil {
+				printError("Failed to update %s: %s", f, err)
+				os.Exit(1)
+			}
+		}
+		if err := sys.RemoveRoot(); err != nil {
+			printError("Failed to remove gm: %s", err)
+			os.Exit(1)
+		}
+
+		msg := fmt.Sprintf("Removed %s of gm data, start a new shell to apply profile changes", formatSize(total))
+		if exePath, err := os.Executable(); err == nil {
+			if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+				exePath = resolved
+			}
+			if !strings.HasPrefix(exePath, root+string(filepath.Separator)) {
+				msg += fmt.Sprintf("\ngm binary is kept, remove %s to uninstall it", exePath)
+			}
+		}
+		fmt.Println(sInfo.Render(msg))
+	},
+}
+
+func init() {
+	implodeCmd.Flags().BoolVarP(&implodeYes, "yes", "y", false, "Remove without asking for confirmation")
+	rootCmd.AddCommand(implodeCmd)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-dvr/gm/importer"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+var (
+	importFrom string
+	importMode string
+	importYes  bool
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Args:  cobra.ExactArgs(0),
+	Short: "Import toolchains installed by another version manager",
+	Long: fmt.Sprintf(`Import toolchains installed by another version manager into gm.
+
+Supported sources: %s.
+Toolchains are copied by default, use --mode to move or hard-link them instead.`, strings.Join(importer.Names(), ", ")),
+	Run: func(cmd *cobra.Command, args []string) {
+		source, err := importer.Get(importFrom)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		mode, err := importer.ParseMode(importMode)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+
+		candidates, err := source.Find()
+		if err != nil {
+			printError("Failed to find toolchains of %s: %s", source.Name(), err)
+			os.Exit(1)
+		}
+		pending, err := filterInstalled(candidates)
+		if err != nil {
+			printError("Failed to list installed versions: %s", err)
+			os.Exit(1)
+		}
+
+		if len(pending) == 0 {
+			fmt.Println(sInfo.Render(fmt.Sprintf("No new toolchains found in %s", source.Name())))
+		} else {
+			importToolchains(source, pending, mode)
+		}
+
+		offerDefault(source)
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFrom, "from", "", "Version manager to import toolchains from ("+strings.Join(importer.Names(), "|")+")")
+	importCmd.Flags().StringVar(&importMode, "mode", string(importer.ModeCopy), "How to bring toolchains into gm (copy|move|link)")
+	importCmd.Flags().BoolVarP(&importYes, "yes", "y", false, "Set default version of imported manager as current without asking")
+	importCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(importCmd)
+}
+
+// filterInstalled drops candidates which are already installed into gm.
+func filterInstalled(candidates []importer.Candidate) ([]importer.Candidate, error) {
+	installed, err := sys.ListInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(installed))
+	for _, tc := range installed {
+		if tc.External || toolchain.IsInstalled(tc.Path) {
+			known["go"+tc.Version] = true
+		}
+	}
+
+	var pending []importer.Candidate
+	for _, c := range candidates {
+		if !known[c.Version] {
+			pending = append(pending, c)
+		}
+	}
+	return pending, nil
+}
+
+func importToolchains(source importer.Source, candidates []importer.Candidate, mode importer.Mode) {
+	// version is read from foreign tree and names its directory, nothing is moved if any is invalid
+	for _, c := range candidates {
+		if err := sys.ValidateVersionName(c.Version); err != nil {
+			from := c.Path
+			if c.Archive != "" {
+				from = c.Archive
+			}
+			printError("Toolchain %s can't be imported: %s", from, err)
+			os.Exit(1)
+		}
+	}
+	tui := pbar.New(fmt.Sprintf("Importing %d toolchain(s) from %s", len(candidates), source.Name()))
+
+	go func() {
+		tracker := tui.GetTracker()
+		for _, c := range candidates {
+			destPath, err := sys.PathForVersion(c.Version)
+			if err != nil {
+				tui.Exit(fmt.Errorf("determine destination path for %s: %w", c.Version, err))
+				return
+			}
+			if v, ok := source.(importer.Verifier); ok {
+				tracker.Reset(fmt.Sprintf("Verifying %s ...", c.Version))
+				if err := v.Verify(c); err != nil {
+					tui.Exit(fmt.Errorf("verify %s: %w", c.Version, err))
+					return
+				}
+			}
+			tracker.Reset(fmt.Sprintf("Importing %s from %s ...", c.Version, source.Name()))
+			if err := importer.Import(c, destPath, mode, tracker); err != nil {
+				tui.Exit(fmt.Errorf("import %s: %w", c.Version, err))
+				return
+			}
+		}
+		tui.SetInfo(fmt.Sprintf("Successfully imported %d toolchain(s)", len(candidates)))
+		tui.Exit(nil)
+	}()
+
+	if err := tui.Run(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// offerDefault suggests to use default version of another version manager as current.
+func offerDefault(source importer.Source) {
+	version, err := source.Default()
+	if err != nil {
+		printError("Failed to determine default version of %s: %s", source.Name(), err)
+		return
+	}
+	if version == "" {
+		return
+	}
+	if err := sys.ValidateVersionName(version); err != nil {
+		printError("Default version of %s is ignored: %s", source.Name(), err)
+		return
+	}
+	current, err := sys.GetCurrentVersion()
+	if err == nil && current != nil && "go"+current.Version == version {
+		return
+	}
+
+	unprefixed := strings.TrimPrefix(version, "go")
+	question := fmt.Sprintf("Version %s is default in %s, set it as current?", unprefixed, source.Name())
+	if !importYes && !confirm(question) {
+		return
+	}
+	if err := sys.SetAsCurrent(version); err != nil {
+		printError("Failed to set current version: %s", err)
+		os.Exit(1)
+	}
+	fmt.Println(sInfo.Render(fmt.Sprintf("Version %s is set as current", unprefixed)))
+}
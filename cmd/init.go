@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/sys"
+)
+
+var (
+	initShell string
+	initUndo  bool
+)
+
+// initCmd represents the init command
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Args:  cobra.ExactArgs(0),
+	Short: "Set up shell profile to use gm",
+	Long: `Add a block marked with begin and end comments to the profile of your shell,
+putting gm in PATH and setting up environment of the current Go version.
+Running it again updates the block in place, lines added by install script
+of older gm versions are replaced with it.
+
+Supported shells are bash (~/.bashrc or ~/.bash_profile), zsh (~/.zshenv),
+fish (config.fish) and nushell (env.nu). The shell is detected from SHELL,
+use --shell to choose another one. Use --undo to remove the block.
+
+On Windows without --shell environment variables are set for the user
+like 'gm env' does.
+
+Example usage:
+gm init
+gm init --shell zsh --undo`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if initShell == "" && runtime.GOOS == "windows" {
+			if initUndo {
+				printError("Removing environment variables set for the user is not supported, use --shell to undo profile changes")
+				os.Exit(1)
+			}
+			if err := sys.PrepareGoEnvs(""); err != nil {
+				printError("Failed to set environment variables: %s", err)
+				os.Exit(1)
+			}
+			fmt.Println(sInfo.Render("Environment variables are set for the user, restart your terminal to apply them"))
+			return
+		}
+
+		name := initShell
+		if name == "" {
+			name = os.Getenv("SHELL")
+		}
+		if name == "" {
+			printError("Failed to detect shell, use --shell to choose one")
+			os.Exit(1)
+		}
+		shell, err := sys.ParseShell(name)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+
+		if initUndo {
+			files, err := sys.ProfileFiles(shell)
+			if err != nil {
+				printError("Failed to determine profile: %s", err)
+				os.Exit(1)
+			}
+			removed := false
+			for _, file := range files {
+				changed, err := sys.RemoveProfileBlock(file)
+				if err != nil {
+					printError("Failed to update %s: %s", file, err)
+					os.Exit(1)
+				}
+				if changed {
+					removed = true
+					fmt.Println(sInfo.Render(fmt.Sprintf("Removed gm block from %s", file)))
+				}
+			}
+			if !removed {
+				fmt.Println(sInfo.Render(fmt.Sprintf("No gm block found in profile of %s", shell)))
+			}
+			return
+		}
+
+		exePath, err := os.Executable()
+		if err != nil {
+			printError("Failed to determine path of executable: %s", err)
+			os.Exit(1)
+		}
+		if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+			exePath = resolved
+		}
+		file, err := sys.ProfileFile(shell)
+		if err != nil {
+			printError("Failed to determine profile: %s", err)
+			os.Exit(1)
+		}
+		block, err := sys.ProfileBlock(shell, filepath.Dir(exePath))
+		if err != nil {
+			printError("Failed to prepare profile block: %s", err)
+			os.Exit(1)
+		}
+		changed, err := sys.AddProfileBlock(file, block)
+		if err != nil {
+			printError("Failed to update %s: %s", file, err)
+			os.Exit(1)
+		}
+		if !changed {
+			fmt.Println(sInfo.Render(fmt.Sprintf("%s is already set up", file)))
+			return
+		}
+		fmt.Println(sInfo.Render(fmt.Sprintf("Added gm block to %s, start a new shell to apply it", file)))
+	},
+}
+
+func init() {
+	initCmd.Flags().StringVar(&initShell, "shell", "", "Shell to set up: bash, zsh, fish or nu (default detected from SHELL)")
+	initCmd.Flags().BoolVar(&initUndo, "undo", false, "Remove gm block from shell profile")
+	rootCmd.AddCommand(initCmd)
+}
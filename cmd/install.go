@@ -4,29 +4,80 @@ Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/x-dvr/gm/progress"
 	"github.com/x-dvr/gm/sys"
 	"github.com/x-dvr/gm/toolchain"
 	"github.com/x-dvr/gm/ui/pbar"
 )
 
+const versionTip = "tip"
+
+var (
+	sourceRef     string
+	sourceRepo    string
+	sourceArchive string
+	updateSource  bool
+	downloadFrom  string
+	verifySumDB   bool
+)
+
 // installCmd represents the install command
 var installCmd = &cobra.Command{
 	Use:     "install",
 	Aliases: []string{"i"},
 	Args:    cobra.MaximumNArgs(1),
 	Short:   "Install specified version of Go toolchain",
-	Long:    fmt.Sprintf("Use %q to install most recent version of toolchain.", versionLatest),
+	Long: fmt.Sprintf(`Use %q to install most recent version of toolchain.
+
+Use %q to build toolchain from the master branch of Go repository,
+or --source to build it from any other git ref (branch, tag, commit or CL ref).
+Use --src-archive to build toolchain from Go source archive.
+
+Binary releases are downloaded from dl.google.com, falling back to
+golang.org/toolchain module from GOPROXY when it fails.
+Use --from to select the download source explicitly.
+Use --sumdb to verify releases from dl.google.com against the checksum
+database from GOSUMDB ("<key> <url>" selects a custom database).
+
+Installed versions are checked against version policy, see 'gm policy'.
+Source builds are checked against VERSION of sources, development builds
+against name of the commit.`, versionLatest, versionTip),
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
 		version := ""
 		if len(args) == 1 {
 			version = args[0]
 		}
+		from, err := toolchain.ParseDownloadSource(downloadFrom)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		fromSource := sourceArchive != "" || sourceRef != "" || updateSource
+		if fromSource && version != "" && version != versionTip {
+			printError("Version %s can not be combined with --source, --update or --src-archive, the version is determined by the sources", version)
+			os.Exit(1)
+		}
+		if sourceArchive != "" {
+			installFromArchive(sourceArchive)
+			return
+		}
+		if version == versionTip || sourceRef != "" || updateSource {
+			ref := sourceRef
+			if ref == "" {
+				ref = "master"
+			}
+			installFromRepo(ref)
+			return
+		}
 		if version == versionLatest || version == "" {
 			version, err = toolchain.GetLatestVersion()
 			if err != nil {
@@ -38,6 +89,11 @@ var installCmd = &cobra.Command{
 			version = "go" + version
 		}
 
+		if err := enforcePolicy("install", version, true); err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+
 		destPath, err := sys.PathForVersion(version)
 		if err != nil {
 			printError("Failed to determine	destination path for installation: %s", err)
@@ -48,7 +104,7 @@ var installCmd = &cobra.Command{
 		tui := pbar.New(fmt.Sprintf("Installing Go %s", unprefixed))
 
 		go func() {
-			err = toolchain.Install(version, destPath, tui.GetTracker())
+			err = installRelease(version, destPath, from, tui.GetTracker())
 			if err != nil {
 				tui.Exit(fmt.Errorf("install toolchain (ver. %s) into path %q: %w", unprefixed, destPath, err))
 				return
@@ -58,6 +114,10 @@ var installCmd = &cobra.Command{
 				tui.Exit(fmt.Errorf("set installed toolchain version %q as current: %w", unprefixed, err))
 				return
 			}
+			if err := enforceQuota(tui.GetTracker()); err != nil {
+				tui.Exit(fmt.Errorf("enforce disk quota: %w", err))
+				return
+			}
 
 			tui.Exit(nil)
 		}()
@@ -69,5 +129,236 @@ var installCmd = &cobra.Command{
 }
 
 func init() {
+	installCmd.Flags().StringVar(&sourceRef, "source", "", "Build toolchain from the given git ref of Go repository")
+	installCmd.Flags().StringVar(&sourceRepo, "repo", toolchain.GoSourceRepo, "Go repository to build toolchain from")
+	installCmd.Flags().StringVar(&sourceArchive, "src-archive", "", "Build toolchain from the given Go source archive")
+	installCmd.Flags().BoolVar(&updateSource, "update", false, "Rebuild the most recent toolchain built from source incrementally")
+	installCmd.Flags().StringVar(&downloadFrom, "from", string(toolchain.SourceAuto), "Download source of binary releases (dl|proxy|auto)")
+	installCmd.Flags().BoolVar(&verifySumDB, "sumdb", false, "Verify releases from dl.google.com against checksum database")
+	installCmd.MarkFlagsMutuallyExclusive("source", "src-archive")
+	addIgnorePolicyFlag(installCmd)
 	rootCmd.AddCommand(installCmd)
 }
+
+// installRelease installs binary release of Go toolchain from the given download source.
+func installRelease(version, destPath string, from toolchain.DownloadSource, tracker progress.IOTracker) error {
+	sumdbCache, err := sys.SumDBCachePath()
+	if err != nil {
+		return fmt.Errorf("determine checksum database cache path: %w", err)
+	}
+	cfg := toolchain.ProxyConfigFromEnv(sumdbCache)
+	if from == toolchain.SourceProxy {
+		return toolchain.InstallFromProxy(version, destPath, cfg, tracker)
+	}
+
+	var db *toolchain.SumDB
+	if verifySumDB {
+		db, err = cfg.SumDB()
+		if errors.Is(err, toolchain.ErrSumDBDisabled) {
+			tracker.Reset("Checksum database is disabled, skipping verification")
+		} else if err != nil {
+			return err
+		}
+	}
+	dlErr := toolchain.Install(version, destPath, db, tracker)
+	if dlErr == nil || from == toolchain.SourceDL {
+		return dlErr
+	}
+	tracker.Reset("Download failed, falling back to GOPROXY ...")
+	if err := toolchain.InstallFromProxy(version, destPath, cfg, tracker); err != nil {
+		return errors.Join(dlErr, fmt.Errorf("fallback to GOPROXY: %w", err))
+	}
+	return nil
+}
+
+func installFromRepo(ref string) {
+	repoPath, err := sys.SourcePath()
+	if err != nil {
+		printError("Failed to determine path of Go repository: %s", err)
+		os.Exit(1)
+	}
+
+	tui := pbar.New(fmt.Sprintf("Building Go from %s", ref))
+
+	go func() {
+		tracker := tui.GetTracker()
+		commit, err := toolchain.FetchSource(repoPath, sourceRepo, ref, tracker)
+		if err != nil {
+			tui.Exit(fmt.Errorf("fetch Go sources: %w", err))
+			return
+		}
+
+		version := sys.TipVersion(toolchain.ShortCommit(commit))
+		destPath, err := sys.PathForVersion(version)
+		if err != nil {
+			tui.Exit(fmt.Errorf("determine destination path for installation: %w", err))
+			return
+		}
+		built := toolchain.IsInstalled(destPath)
+		if !built {
+			if err := prepareSourceTree(repoPath, commit, destPath, tracker); err != nil {
+				tui.Exit(err)
+				return
+			}
+		}
+		// VERSION of release branches, name of commit for development builds
+		if err := enforcePolicy("install", toolchainVersion(destPath), true); err != nil {
+			tui.Exit(err)
+			return
+		}
+		if built {
+			tracker.Reset(fmt.Sprintf("Commit %s is already built", toolchain.ShortCommit(commit)))
+		} else {
+			rec := toolchain.InstallRecord{Source: sourceRepo + "@" + commit}
+			if err := buildToolchain(destPath, rec, tracker); err != nil {
+				tui.Exit(err)
+				return
+			}
+		}
+
+		if err := sys.SetAsCurrent(version); err != nil {
+			tui.Exit(fmt.Errorf("set built toolchain %q as current: %w", version, err))
+			return
+		}
+		if err := enforceQuota(tracker); err != nil {
+			tui.Exit(fmt.Errorf("enforce disk quota: %w", err))
+			return
+		}
+		tui.SetInfo(fmt.Sprintf("Successfully built %s", version))
+		tui.Exit(nil)
+	}()
+
+	if err := tui.Run(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// prepareSourceTree checks out commit into destPath, reusing the most recent
+// source build when incremental update was requested.
+func prepareSourceTree(repoPath, commit, destPath string, tracker progress.IOTracker) error {
+	if _, err := os.Stat(destPath); err == nil {
+		// leftover of the failed build of the same commit
+		return toolchain.UpdateSource(repoPath, commit, destPath, destPath, tracker)
+	}
+	if updateSource {
+		latest, err := sys.FindLatestTip()
+		if err != nil {
+			return fmt.Errorf("find previous source build: %w", err)
+		}
+		if latest != nil {
+			if err := toolchain.UpdateSource(repoPath, commit, latest.Path, destPath, tracker); err != nil {
+				return fmt.Errorf("update source tree: %w", err)
+			}
+			if current, err := sys.GetCurrentVersion(); err == nil && current != nil && current.Path == latest.Path {
+				// symlink points to the moved directory
+				if err := sys.SetAsCurrent(filepath.Base(destPath)); err != nil {
+					return fmt.Errorf("update current version: %w", err)
+				}
+			}
+			return nil
+		}
+	}
+	if err := toolchain.CheckoutSource(repoPath, commit, destPath, tracker); err != nil {
+		return fmt.Errorf("checkout source tree: %w", err)
+	}
+	return nil
+}
+
+func installFromArchive(archive string) {
+	tui := pbar.New(fmt.Sprintf("Building Go from %s", filepath.Base(archive)))
+
+	go func() {
+		tracker := tui.GetTracker()
+		tmpPath, err := sys.PathForVersion(".src-" + strconv.Itoa(os.Getpid()))
+		if err != nil {
+			tui.Exit(fmt.Errorf("determine destination path for installation: %w", err))
+			return
+		}
+		defer os.RemoveAll(tmpPath)
+
+		archiveSum, err := toolchain.FileSHA256(archive)
+		if err != nil {
+			tui.Exit(fmt.Errorf("hash source archive: %w", err))
+			return
+		}
+		if err := toolchain.UnpackSource(archive, tmpPath, tracker); err != nil {
+			tui.Exit(err)
+			return
+		}
+		version, err := toolchain.ReadVersion(tmpPath)
+		if err != nil {
+			tui.Exit(fmt.Errorf("read version of Go sources: %w", err))
+			return
+		}
+		if version == "" {
+			tui.Exit(fmt.Errorf("archive %s does not contain VERSION file", archive))
+			return
+		}
+		// VERSION of archive names destination, it must stay inside versions directory
+		destPath, err := sys.PathForName(version)
+		if err != nil {
+			tui.Exit(fmt.Errorf("determine destination path for installation: %w", err))
+			return
+		}
+		if err := enforcePolicy("install", version, true); err != nil {
+			tui.Exit(err)
+			return
+		}
+		if toolchain.IsInstalled(destPath) {
+			tui.Exit(fmt.Errorf("version %s is already installed", strings.TrimPrefix(version, "go")))
+			return
+		}
+		if err := os.RemoveAll(destPath); err != nil {
+			tui.Exit(fmt.Errorf("cleanup previous installation: %w", err))
+			return
+		}
+		if err := os.Rename(tmpPath, destPath); err != nil {
+			tui.Exit(fmt.Errorf("move sources into %q: %w", destPath, err))
+			return
+		}
+		source, err := filepath.Abs(archive)
+		if err != nil {
+			source = archive
+		}
+		rec := toolchain.InstallRecord{Source: source, SHA256: archiveSum}
+		if err := buildToolchain(destPath, rec, tracker); err != nil {
+			tui.Exit(err)
+			return
+		}
+
+		if err := sys.SetAsCurrent(version); err != nil {
+			tui.Exit(fmt.Errorf("set built toolchain %q as current: %w", version, err))
+			return
+		}
+		if err := enforceQuota(tracker); err != nil {
+			tui.Exit(fmt.Errorf("enforce disk quota: %w", err))
+			return
+		}
+		tui.SetInfo(fmt.Sprintf("Successfully built %s", version))
+		tui.Exit(nil)
+	}()
+
+	if err := tui.Run(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// buildToolchain runs make script in Go source tree at goroot
+// with suitable installed toolchain as GOROOT_BOOTSTRAP.
+func buildToolchain(goroot string, rec toolchain.InstallRecord, tracker progress.IOTracker) error {
+	required, err := toolchain.BootstrapRequirement(goroot)
+	if err != nil {
+		return fmt.Errorf("determine bootstrap requirement: %w", err)
+	}
+	bootstrap, err := sys.FindBootstrap(required)
+	if err != nil {
+		if errors.Is(err, sys.ErrNoBootstrap) {
+			return fmt.Errorf("%w: install it first with 'gm install %s'", err, strings.TrimPrefix(required, "go"))
+		}
+		return fmt.Errorf("find bootstrap toolchain: %w", err)
+	}
+	if err := toolchain.BuildFromSource(goroot, bootstrap.Path, rec, tracker); err != nil {
+		return fmt.Errorf("build toolchain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// linkCmd represents the link command
+var linkCmd = &cobra.Command{
+	Use:   "link <name> <path>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Register Go toolchain installed outside of gm under the given name",
+	Long: `Register Go toolchain installed outside of gm (e.g. /usr/local/go,
+distro package or locally patched build) under the given name.
+Registered toolchain can be used with 'gm use <name>'. Its files are never
+modified by gm, 'gm uninstall <name>' only unregisters it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, path := args[0], args[1]
+		if !strings.HasPrefix(name, "go") {
+			name = "go" + name
+		}
+		registerExternal(name, path)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+}
+
+func registerExternal(version, path string) {
+	if err := toolchain.CheckTree(path); err != nil {
+		printError("Failed to register toolchain: %s", err)
+		os.Exit(1)
+	}
+
+	unprefixed := strings.TrimPrefix(version, "go")
+	if err := sys.Link(version, path); err != nil {
+		if errors.Is(err, sys.ErrInstalled) {
+			printError("Version %s is already installed, use 'gm link <name> %s' to register it under another name", unprefixed, path)
+			os.Exit(1)
+		}
+		printError("Failed to register toolchain: %s", err)
+		os.Exit(1)
+	}
+	fmt.Println(sInfo.Render(fmt.Sprintf("Registered %s as %s", path, unprefixed)))
+}
@@ -4,31 +4,97 @@ Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"text/template"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/x-dvr/gm/gover"
 	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
 )
 
+var (
+	listJSON        bool
+	listFormat      string
+	listUnsupported bool
+)
+
+// listEntry describes installed toolchain in structured output of list command.
+type listEntry struct {
+	Version  string `json:"version"`
+	Path     string `json:"path"`
+	External bool   `json:"external"`
+	Current  bool   `json:"current"`
+	// Size is disk usage of toolchain in bytes
+	Size        int64     `json:"size"`
+	Source      string    `json:"source,omitempty"`
+	InstalledAt time.Time `json:"installed_at,omitzero"`
+	LastUsedAt  time.Time `json:"last_used_at,omitzero"`
+	// Latest is the newest published patch release of the same minor line
+	Latest      string `json:"latest,omitempty"`
+	Outdated    bool   `json:"outdated"`
+	Unsupported bool   `json:"unsupported"`
+	// UnsupportedSince is the first release of minor line which ended support of the version
+	UnsupportedSince string `json:"unsupported_since,omitempty"`
+}
+
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Args:    cobra.ExactArgs(0),
 	Short:   "List all installed versions of Go toolchain",
+	Long: `List all installed versions of Go toolchain.
+
+When output is not a terminal, only versions are printed one per line.
+Use --json or --format to get details in machine readable form, e.g.:
+	gm list --format '{{.Version}} {{.Path}} {{.Size}}'
+Template fields are the same as keys of JSON output:
+Version, Path, External, Current, Size, Source, InstalledAt, LastUsedAt,
+Latest, Outdated, Unsupported and UnsupportedSince.
+
+Use --unsupported to list only versions of minor lines no longer supported by Go team,
+e.g. to remove them:
+	gm list --unsupported | xargs -n1 gm uninstall`,
 	Run: func(cmd *cobra.Command, args []string) {
+		var tmpl *template.Template
+		if listFormat != "" {
+			var err error
+			tmpl, err = template.New("format").Parse(listFormat)
+			if err != nil {
+				printError("Invalid format template: %s", err)
+				os.Exit(1)
+			}
+		}
+
 		installed, err := sys.ListInstalledVersions()
 		if err != nil {
 			printError("Failed to list installed versions: %s", err)
 			os.Exit(1)
 		}
 
-		fmt.Println(sTitleBar.Render(sTitle.Render("Installed versions of Go")))
-		if len(installed) == 0 {
-			fmt.Println(sPadLeft.Render(sInfo.Render("No Go versions found")))
+		var catalog *toolchain.Catalog
+		if listUnsupported {
+			catalog, err = loadCatalog()
+			if err != nil {
+				printError("Failed to load release catalog: %s", err)
+				os.Exit(1)
+			}
+			installed = slices.DeleteFunc(installed, func(tc sys.Toolchain) bool {
+				return !catalog.Status(tc.Version).Unsupported
+			})
+		}
+
+		if !listJSON && tmpl == nil && !isTerminal() {
+			for _, tc := range installed {
+				fmt.Println(tc.Version)
+			}
 			return
 		}
 
@@ -37,25 +103,164 @@ var listCmd = &cobra.Command{
 			printError("Failed to determine current version: %s", err)
 			os.Exit(1)
 		}
-
-		items := make([]string, 0, len(installed))
-
-		for _, toolchain := range installed {
-			if toolchain.Version == current.Version {
-				text := sActiveText.Render(toolchain.Version + " - current")
-				sub := sSubtext.Render(toolchain.Path)
-				items = append(items, sActiveListItem.Render(text+"\n"+sub))
-			} else {
-				text := sText.Render(toolchain.Version)
-				sub := sSubtext.Render(toolchain.Path)
-				items = append(items, sListItem.Render(text+"\n"+sub))
+		if catalog == nil {
+			catalog, err = loadCatalog()
+			if err != nil {
+				printError("Release catalog is not available, status of versions is unknown: %s", err)
+			}
+		}
+		entries := make([]listEntry, 0, len(installed))
+		for _, tc := range installed {
+			entry, err := newListEntry(tc, current, catalog)
+			if err != nil {
+				printError("Failed to describe version %s: %s", tc.Version, err)
+				os.Exit(1)
 			}
+			entries = append(entries, entry)
 		}
 
-		fmt.Println(sPadLeft.Render(lipgloss.JoinVertical(lipgloss.Left, items...)))
+		switch {
+		case listJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(entries); err != nil {
+				printError("Failed to write JSON: %s", err)
+				os.Exit(1)
+			}
+		case tmpl != nil:
+			for _, entry := range entries {
+				if err := tmpl.Execute(os.Stdout, entry); err != nil {
+					printError("Failed to format version %s: %s", entry.Version, err)
+					os.Exit(1)
+				}
+				fmt.Println()
+			}
+		default:
+			renderList(installed, entries)
+		}
 	},
 }
 
 func init() {
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print installed versions as JSON")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print each installed version using Go template")
+	listCmd.Flags().BoolVar(&listUnsupported, "unsupported", false, "List only versions no longer supported by Go team")
+	listCmd.MarkFlagsMutuallyExclusive("json", "format")
 	rootCmd.AddCommand(listCmd)
 }
+
+// newListEntry collects details of installed toolchain, catalog may be nil.
+func newListEntry(tc sys.Toolchain, current *sys.Toolchain, catalog *toolchain.Catalog) (listEntry, error) {
+	size, err := toolchain.DiskUsage(tc.Path)
+	if err != nil {
+		return listEntry{}, err
+	}
+	entry := listEntry{
+		Version:  tc.Version,
+		Path:     tc.Path,
+		External: tc.External,
+		Current:  current != nil && tc.Version == current.Version,
+		Size:     size,
+	}
+	if rec := tc.Record; rec != nil {
+		entry.Source = rec.Source
+		entry.InstalledAt = rec.InstalledAt
+		entry.LastUsedAt = rec.LastUsedAt
+	}
+	if catalog != nil {
+		st := catalog.Status(tc.Version)
+		entry.Latest = st.Latest
+		entry.Outdated = st.Outdated
+		entry.Unsupported = st.Unsupported
+		entry.UnsupportedSince = st.UnsupportedSince
+	}
+	return entry, nil
+}
+
+// renderList prints installed toolchains grouped by minor release line.
+func renderList(installed []sys.Toolchain, entries []listEntry) {
+	fmt.Println(sTitleBar.Render(sTitle.Render("Installed versions of Go")))
+	if len(installed) == 0 {
+		fmt.Println(sPadLeft.Render(sInfo.Render("No Go versions found")))
+		return
+	}
+
+	byVersion := make(map[string]listEntry, len(entries))
+	for _, entry := range entries {
+		byVersion[entry.Version] = entry
+	}
+
+	var items []string
+	for _, group := range gover.GroupByLang(installed, func(tc sys.Toolchain) string { return tc.Version }) {
+		title := "other builds"
+		if group.Lang != (gover.Version{}) {
+			title = group.Lang.String()
+		}
+		items = append(items, sGroupTitle.Render(title))
+		for _, tc := range group.Items {
+			items = append(items, listItem(tc, byVersion[tc.Version]))
+		}
+	}
+
+	fmt.Println(sPadLeft.Render(lipgloss.JoinVertical(lipgloss.Left, items...)))
+}
+
+// listItem renders installed toolchain, current one is highlighted.
+func listItem(tc sys.Toolchain, entry listEntry) string {
+	label := tc.Version
+	if tc.External {
+		label += " - external"
+	}
+	sub := sSubtext.Render(tc.Path + " (" + formatSize(entry.Size) + ")")
+	if details := recordDetails(tc); details != "" {
+		sub += "\n" + sSubtext.Render(details)
+	}
+	if tc.VersionMismatch() {
+		sub += "\n" + sErrorText.Render(fmt.Sprintf("directory name does not match installed version %s", tc.Record.Version))
+	}
+	if entry.Unsupported {
+		sub += "\n" + sWarningText.Render(unsupportedText(entry.UnsupportedSince))
+	} else if entry.Outdated {
+		sub += "\n" + sInfoText.Render(fmt.Sprintf("outdated, %s is available", entry.Latest))
+	}
+	if entry.Current {
+		text := sActiveText.Render(label + " - current")
+		return sActiveListItem.Render(text + "\n" + sub)
+	}
+	text := sText.Render(label)
+	if entry.Unsupported {
+		text = sWarningText.Render(label + " - unsupported")
+	}
+	return sListItem.Render(text + "\n" + sub)
+}
+
+// recordDetails describes installation of toolchain.
+func recordDetails(tc sys.Toolchain) string {
+	rec := tc.Record
+	if rec == nil {
+		return ""
+	}
+	const layout = "2006-01-02"
+	details := "installed " + rec.InstalledAt.Local().Format(layout)
+	if rec.Source != "" {
+		details += " from " + rec.Source
+	}
+	if !rec.LastUsedAt.IsZero() {
+		details += ", last used " + rec.LastUsedAt.Local().Format(layout)
+	}
+	return details
+}
+
+// formatSize formats size in bytes for humans.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// defaultLockPlatforms are locked unless --platform is given or lock file lists other platforms.
+var defaultLockPlatforms = []string{"darwin/amd64", "darwin/arm64", "linux/amd64", "linux/arm64", "windows/amd64"}
+
+var lockPlatforms []string
+
+// lockCmd represents the lock command
+var lockCmd = &cobra.Command{
+	Use:   "lock [version...]",
+	Short: "Generate or refresh gm.lock with checksums of required Go versions",
+	Long: fmt.Sprintf(`Generate or refresh %s with SHA-256 checksums of release archives of
+the given Go versions for each platform, taken from the release catalog.
+Lock file of repository in working directory or its parents is refreshed,
+new one is created in working directory.
+
+Versions given as arguments replace the locked ones, without
+arguments versions already listed in lock file are refreshed.
+Platforms are taken from --platform, the existing lock file or default
+to %s.
+
+Use 'gm sync' to install locked versions.`, project.LockFile, strings.Join(defaultLockPlatforms, ", ")),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := os.Getwd()
+		if err != nil {
+			printError("Failed to determine working directory: %s", err)
+			os.Exit(1)
+		}
+		lock, err := project.FindLock(dir)
+		if err != nil {
+			printError("Failed to read %s: %s", project.LockFile, err)
+			os.Exit(1)
+		}
+		if lock == nil {
+			lock = &project.Lock{Dir: dir}
+		}
+
+		versions := lock.Versions()
+		if len(args) > 0 {
+			versions = nil
+			for _, v := range args {
+				v = "go" + strings.TrimPrefix(v, "go")
+				if !gover.IsValid(v) {
+					printError("Invalid Go version %q", v)
+					os.Exit(1)
+				}
+				versions = append(versions, v)
+			}
+		}
+		if len(versions) == 0 {
+			printError("No versions to lock, specify them as arguments")
+			os.Exit(1)
+		}
+		platforms := lockPlatforms
+		if len(platforms) == 0 {
+			platforms = lock.Platforms()
+		}
+		if len(platforms) == 0 {
+			platforms = slices.Clone(defaultLockPlatforms)
+			if current := runtime.GOOS + "/" + runtime.GOARCH; !slices.Contains(platforms, current) {
+				platforms = append(platforms, current)
+			}
+		}
+
+		for _, p := range platforms {
+			if goos, goarch, ok := strings.Cut(p, "/"); !ok || goos == "" || goarch == "" {
+				printError("Invalid platform %q, expected GOOS/GOARCH", p)
+				os.Exit(1)
+			}
+		}
+
+		catalog, err := lockCatalog(versions, platforms)
+		if err != nil {
+			printError("Failed to load release catalog: %s", err)
+			os.Exit(1)
+		}
+
+		updated := &project.Lock{Dir: lock.Dir}
+		var missing []string
+		for _, v := range versions {
+			for _, p := range platforms {
+				goos, goarch, _ := strings.Cut(p, "/")
+				f, ok := catalog.Archive(v, goos, goarch)
+				if !ok {
+					missing = append(missing, v+" "+p)
+					continue
+				}
+				updated.Set(v, p, f.SHA256)
+			}
+		}
+		if len(missing) > 0 && !slices.ContainsFunc(catalog.Releases, func(r toolchain.Release) bool { return len(r.Files) > 0 }) {
+			// catalog listed by module proxy has no files
+			printError("Release catalog has no checksums of release archives, go.dev is not reachable")
+			os.Exit(1)
+		}
+		if len(missing) > 0 {
+			printError("No release archives in release catalog for: %s", strings.Join(missing, ", "))
+			os.Exit(1)
+		}
+		if err := updated.Write(); err != nil {
+			printError("Failed to write %s: %s", project.LockFile, err)
+			os.Exit(1)
+		}
+
+		lines := make([]string, 0, len(versions)+1)
+		for _, v := range versions {
+			lines = append(lines, sText.Render(strings.TrimPrefix(v, "go")))
+		}
+		lines = append(lines, sActiveText.Render(fmt.Sprintf("Locked %d version(s) for %d platform(s) in %s",
+			len(versions), len(platforms), updated.Dir)))
+		fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+	},
+}
+
+func init() {
+	lockCmd.Flags().StringSliceVar(&lockPlatforms, "platform", nil, "Platforms to lock as GOOS/GOARCH (repeatable)")
+	rootCmd.AddCommand(lockCmd)
+}
+
+// lockCatalog returns release catalog listing archives of all versions for all platforms.
+// Cached catalog is refreshed if it misses any of them, e.g. for a fresh release.
+func lockCatalog(versions, platforms []string) (*toolchain.Catalog, error) {
+	catalog, err := loadCatalog()
+	if err == nil && hasArchives(catalog, versions, platforms) {
+		return catalog, nil
+	}
+	cacheFile, err := sys.CatalogPath()
+	if err != nil {
+		return nil, fmt.Errorf("determine release catalog path: %w", err)
+	}
+	sumdbCache, err := sys.SumDBCachePath()
+	if err != nil {
+		return nil, fmt.Errorf("determine checksum database cache path: %w", err)
+	}
+	return toolchain.LoadCatalog(cacheFile, 0, toolchain.ProxyConfigFromEnv(sumdbCache))
+}
+
+func hasArchives(catalog *toolchain.Catalog, versions, platforms []string) bool {
+	for _, v := range versions {
+		for _, p := range platforms {
+			goos, goarch, _ := strings.Cut(p, "/")
+			if _, ok := catalog.Archive(v, goos, goarch); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/matrix"
+	"github.com/x-dvr/gm/sys"
+)
+
+var (
+	matrixVersions       []string
+	matrixJobs           int
+	matrixCapture        bool
+	matrixReport         string
+	matrixInstallMissing bool
+)
+
+// matrixCmd represents the matrix command
+var matrixCmd = &cobra.Command{
+	Use:   "matrix --versions <version,...> [--] <command> [args...]",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Run command with each of several Go toolchains",
+	Long: fmt.Sprintf(`Run command once per Go toolchain, each in its own environment set like
+'gm env' does but pointing directly at the toolchain. GOTOOLCHAIN is set
+to local, so go command does not switch toolchains.
+
+Versions are given with --versions:
+	1.21     the newest installed release of minor line, or the newest
+	         published one when none is installed
+	1.22.4   exact version
+	%-8s the newest published stable release
+
+Runs are concurrent, up to --jobs at once. Output lines are prefixed with
+version, use --capture to print output of each run when it finishes.
+Summary is printed at the end, --report writes it for CI in JUnit XML
+format (.xml file) or JSON (any other file). Versions must be installed
+unless --install-missing is given, and must be allowed by policy.
+
+Example usage:
+gm matrix --versions 1.21,1.22,stable -- go test ./...`, sys.VersionStable),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(matrixVersions) == 0 {
+			printError("No versions given, use --versions")
+			os.Exit(1)
+		}
+		selected := resolveMatrix()
+		for _, mv := range selected {
+			if err := enforcePolicy("matrix", mv.Version, true); err != nil {
+				printError("%s", err)
+				os.Exit(1)
+			}
+		}
+		var missing, names []string
+		for _, mv := range selected {
+			if mv.Installed == nil {
+				missing = append(missing, mv.Version)
+				names = append(names, strings.TrimPrefix(mv.Version, "go"))
+			}
+		}
+		if len(missing) > 0 {
+			if !matrixInstallMissing {
+				printError("Go %s not installed, use --install-missing to install", strings.Join(names, ", "))
+				os.Exit(1)
+			}
+			installMissing(missing)
+			selected = resolveMatrix()
+		}
+
+		jobs := make([]matrix.Job, 0, len(selected))
+		for _, mv := range selected {
+			if mv.Installed == nil {
+				printError("Go %s is not installed", strings.TrimPrefix(mv.Version, "go"))
+				os.Exit(1)
+			}
+			env, err := sys.IsolatedEnv(mv.Installed.Path, os.Environ())
+			if err != nil {
+				printError("Failed to prepare environment: %s", err)
+				os.Exit(1)
+			}
+			jobs = append(jobs, matrix.Job{Name: strings.TrimPrefix(mv.Version, "go"), Env: env})
+		}
+
+		// interrupted runs are killed
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		runner := &matrix.Runner{Args: args, Parallel: matrixJobs, Done: printMatrixResult}
+		if !matrixCapture {
+			runner.Out = os.Stdout
+		}
+		results := runner.Run(ctx, jobs)
+
+		fmt.Println(matrixSummary(results))
+		if matrixReport != "" {
+			if err := matrix.NewReport(args, results).WriteFile(matrixReport); err != nil {
+				printError("Failed to write report: %s", err)
+				os.Exit(1)
+			}
+		}
+		for _, res := range results {
+			if !res.Passed() {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	matrixCmd.Flags().StringSliceVar(&matrixVersions, "versions", nil, "Comma separated versions to run command with")
+	matrixCmd.Flags().IntVarP(&matrixJobs, "jobs", "j", 2, "Number of runs at once, 0 for no limit")
+	matrixCmd.Flags().BoolVar(&matrixCapture, "capture", false, "Print output of each run when it finishes")
+	matrixCmd.Flags().StringVar(&matrixReport, "report", "", "Write report to file (.xml for JUnit, JSON otherwise)")
+	matrixCmd.Flags().BoolVar(&matrixInstallMissing, "install-missing", false, "Install missing versions")
+	// flags after the command belong to it
+	matrixCmd.Flags().SetInterspersed(false)
+	addIgnorePolicyFlag(matrixCmd)
+	rootCmd.AddCommand(matrixCmd)
+}
+
+// resolveMatrix selects toolchains for versions given with --versions.
+func resolveMatrix() []sys.MatrixVersion {
+	installed, err := sys.ListInstalledVersions()
+	if err != nil {
+		printError("Failed to list installed versions: %s", err)
+		os.Exit(1)
+	}
+	// without catalog stable and missing lines are resolved from installed versions
+	catalog, _ := loadCatalog()
+	selected, err := sys.ResolveMatrix(matrixVersions, installed, catalog)
+	if err != nil {
+		printError("Failed to select versions: %s", err)
+		os.Exit(1)
+	}
+	return selected
+}
+
+// printMatrixResult prints result of finished run, preceded by its output with --capture.
+func printMatrixResult(res matrix.Result) {
+	if matrixCapture {
+		fmt.Println(sPadLeft.Render(sGroupTitle.Render("Go " + res.Name)))
+		os.Stdout.Write(res.Output)
+		if n := len(res.Output); n > 0 && res.Output[n-1] != '\n' {
+			fmt.Println()
+		}
+	}
+	status, style := matrixStatus(res)
+	text := fmt.Sprintf("Go %s: %s in %s", res.Name, status, formatDuration(res.Duration))
+	if res.ExitCode < 0 && res.Err != nil {
+		text += ": " + res.Err.Error()
+	}
+	fmt.Println(sPadLeft.Render(style.Render(text)))
+}
+
+// matrixSummary renders table of results.
+func matrixSummary(results []matrix.Result) string {
+	width := len("VERSION")
+	for _, res := range results {
+		width = max(width, len(res.Name))
+	}
+	row := fmt.Sprintf("%%-%ds  %%-14s  %%s", width)
+	lines := []string{
+		sGroupTitle.Render("Summary"),
+		sSubtext.Render(fmt.Sprintf(row, "VERSION", "STATUS", "DURATION")),
+	}
+	passed := 0
+	for _, res := range results {
+		if res.Passed() {
+			passed++
+		}
+		status, style := matrixStatus(res)
+		lines = append(lines, style.Render(fmt.Sprintf(row, res.Name, status, formatDuration(res.Duration))))
+	}
+	lines = append(lines, "", sText.Render(fmt.Sprintf("%d of %d passed", passed, len(results))))
+	return sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+}
+
+// matrixStatus describes result of run and returns style to render it with.
+func matrixStatus(res matrix.Result) (string, lipgloss.Style) {
+	switch {
+	case res.Passed():
+		return "pass", sActiveText
+	case res.ExitCode >= 0:
+		return fmt.Sprintf("fail (exit %d)", res.ExitCode), sErrorText
+	default:
+		return "error", sErrorText
+	}
+}
+
+// formatDuration rounds duration for display.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
@@ -0,0 +1,57 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/sys"
+)
+
+// outdatedCmd represents the outdated command
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Args:  cobra.ExactArgs(0),
+	Short: "List installed minor lines of Go with newer patch releases available",
+	Run: func(cmd *cobra.Command, args []string) {
+		updates, err := findUpdates()
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		if len(updates) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("All installed versions are up to date")))
+			return
+		}
+
+		lines := make([]string, 0, len(updates))
+		for _, u := range updates {
+			lines = append(lines, sText.Render(fmt.Sprintf("%s: %s -> %s", u.Lang, u.Installed.Version, strings.TrimPrefix(u.Latest, "go"))))
+		}
+		fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+		fmt.Println(sPadLeft.Render(sInfo.Render("Run 'gm update' to install them")))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+// findUpdates compares installed versions with release catalog.
+func findUpdates() ([]sys.Update, error) {
+	installed, err := sys.ListInstalledVersions()
+	if err != nil {
+		return nil, fmt.Errorf("list installed versions: %w", err)
+	}
+	catalog, err := loadCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("load release catalog: %w", err)
+	}
+	return sys.FindUpdates(installed, catalog), nil
+}
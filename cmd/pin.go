@@ -0,0 +1,150 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+var pinFormat string
+
+// pinCmd represents the pin command
+var pinCmd = &cobra.Command{
+	Use:   "pin [version]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Pin version of Go toolchain for project in working directory",
+	Long: fmt.Sprintf(`Pin version of Go toolchain for project in working directory.
+Current version is pinned when no version is given, use %q to pin
+the most recent release. Version is installed if it is missing.
+
+Version is recorded in one of the formats:
+	go-version     .go-version file
+	tool-versions  golang entry of .tool-versions file (asdf, mise)
+	go.mod         toolchain directive of go.mod
+Format is taken from --format, the format of existing pin, "pin_format"
+from config or go-version, in that order.
+
+Pinned version is active in the project with 'gm exec' or in shell
+with hook from 'gm env --hook'.`, versionLatest),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := os.Getwd()
+		if err != nil {
+			printError("Failed to determine working directory: %s", err)
+			os.Exit(1)
+		}
+		format, err := resolvePinFormat(dir)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+
+		version := ""
+		if len(args) == 1 {
+			version = args[0]
+		}
+		switch version {
+		case "":
+			current, err := sys.GetCurrentVersion()
+			if err != nil || current == nil {
+				printError("No version given and no version of Go toolchain is set as current")
+				os.Exit(1)
+			}
+			version = current.Version
+		case versionLatest:
+			version, err = toolchain.GetLatestVersion()
+			if err != nil {
+				printError("Failed to get latest Go version: %s", err)
+				os.Exit(1)
+			}
+		}
+		if !strings.HasPrefix(version, "go") {
+			version = "go" + version
+		}
+		if !gover.IsValid(version) {
+			printError("Invalid Go version %q", version)
+			os.Exit(1)
+		}
+
+		if err := enforcePolicy("pin", version, true); err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+
+		unprefixed := strings.TrimPrefix(version, "go")
+		tui := pbar.New(fmt.Sprintf("Pinning Go %s", unprefixed))
+
+		go func() {
+			tracker := tui.GetTracker()
+			if _, err := sys.GoRoot(version); err != nil {
+				destPath, err := sys.PathForVersion(version)
+				if err != nil {
+					tui.Exit(fmt.Errorf("determine destination path for installation: %w", err))
+					return
+				}
+				if err := installRelease(version, destPath, toolchain.SourceAuto, tracker); err != nil {
+					tui.Exit(fmt.Errorf("install toolchain (ver. %s) into path %q: %w", unprefixed, destPath, err))
+					return
+				}
+			}
+			if err := project.Write(dir, version, format); err != nil {
+				tui.Exit(fmt.Errorf("pin version: %w", err))
+				return
+			}
+			// pinned version of working directory is kept by quota
+			if err := enforceQuota(tracker, version); err != nil {
+				tui.Exit(fmt.Errorf("enforce disk quota: %w", err))
+				return
+			}
+			tui.SetInfo(fmt.Sprintf("Pinned Go %s in %s, run commands with 'gm exec' or enable shell hook with 'eval \"$(gm env --hook)\"'",
+				unprefixed, format.File()))
+			tui.Exit(nil)
+		}()
+
+		if err := tui.Run(); err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	pinCmd.Flags().StringVar(&pinFormat, "format", "", "Pin format (go-version|tool-versions|go.mod)")
+	addIgnorePolicyFlag(pinCmd)
+	rootCmd.AddCommand(pinCmd)
+}
+
+// resolvePinFormat returns format of pin for project in dir.
+func resolvePinFormat(dir string) (project.Format, error) {
+	if pinFormat != "" {
+		return project.ParseFormat(pinFormat)
+	}
+	pin, err := project.Find(dir)
+	if err != nil {
+		return "", fmt.Errorf("find pinned version: %w", err)
+	}
+	if pin != nil && pin.Dir == dir {
+		return pin.Format, nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", fmt.Errorf("load config: %w", err)
+	}
+	if cfg.PinFormat != "" {
+		format, err := project.ParseFormat(cfg.PinFormat)
+		if err != nil {
+			return "", fmt.Errorf("invalid config: pin_format: %w", err)
+		}
+		return format, nil
+	}
+	return project.FormatGoVersion, nil
+}
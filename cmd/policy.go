@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/policy"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var ignorePolicy bool
+
+// policyCmd represents the policy command
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Args:  cobra.ExactArgs(0),
+	Short: "Show policies in effect and check installed versions against them",
+	Long: fmt.Sprintf(`Show policies in effect in working directory and check installed versions against them.
+
+Policy is read from file given by "policy" in config and from %s
+of repository in working directory or its parents. Version must satisfy
+all policies in effect:
+	minimum             the oldest allowed version
+	allow               ranges of allowed versions
+	deny                ranges of forbidden versions
+	forbid_unsupported  forbid versions of minor lines no longer supported
+	forbid_vulnerable   forbid versions with known vulnerabilities
+	                    (vulnerability database cached by 'gm audit')
+Range is a minor line ("1.21"), exact version ("1.21.3") or comparisons
+(">=1.21.0 <1.21.5").
+
+Policies are enforced by install, pin, use, exec and the shell hook.
+Use --ignore-policy of these commands in emergencies, every use is logged.`, policy.File),
+	Run: func(cmd *cobra.Command, args []string) {
+		policies, err := loadPolicies()
+		if err != nil {
+			printError("Failed to load policy: %s", err)
+			os.Exit(1)
+		}
+		if len(policies) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("No policy in effect")))
+			return
+		}
+		installed, err := sys.ListInstalledVersions()
+		if err != nil {
+			printError("Failed to list installed versions: %s", err)
+			os.Exit(1)
+		}
+		facts := policyFacts(policies, true)
+
+		var lines []string
+		for _, p := range policies {
+			lines = append(lines, sActiveText.Render("Policy "+p.Path))
+		}
+		for _, tc := range installed {
+			var v *policy.Violation
+			if err := checkPolicies(policies, exactVersion(tc), facts); errors.As(err, &v) {
+				lines = append(lines, sWarningText.Render(fmt.Sprintf("%s - forbidden: %s", tc.Version, v.Reason)))
+			} else {
+				lines = append(lines, sText.Render(tc.Version+" - allowed"))
+			}
+		}
+		fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+}
+
+// addIgnorePolicyFlag registers --ignore-policy flag of command enforcing policy.
+func addIgnorePolicyFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&ignorePolicy, "ignore-policy", false, "Ignore version policy in emergencies, the use is logged")
+}
+
+// loadPolicies returns policies in effect in working directory:
+// policy file from config and policy file of repository.
+func loadPolicies() ([]*policy.Policy, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	var files []string
+	if cfg.Policy != "" {
+		files = append(files, cfg.Policy)
+	}
+	file, err := policy.Find(".")
+	if err != nil {
+		return nil, err
+	}
+	if file != "" && !slices.Contains(files, file) {
+		files = append(files, file)
+	}
+
+	policies := make([]*policy.Policy, 0, len(files))
+	for _, f := range files {
+		p, err := policy.Load(f)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// policyFacts returns data needed by policies. Release catalog is fetched
+// only if online is set, otherwise cached catalog is used. Vulnerabilities are
+// always looked up in database cached by previous audits.
+func policyFacts(policies []*policy.Policy, online bool) policy.Facts {
+	var facts policy.Facts
+	if slices.ContainsFunc(policies, func(p *policy.Policy) bool { return p.ForbidUnsupported }) {
+		if online {
+			facts.Catalog, _ = loadCatalog()
+		} else if file, err := sys.CatalogPath(); err == nil {
+			facts.Catalog, _ = toolchain.CachedCatalog(file)
+		}
+	}
+	if slices.ContainsFunc(policies, func(p *policy.Policy) bool { return p.ForbidVulnerable }) {
+		if client, err := vulnClient(true); err == nil {
+			facts.Vulnerabilities = func(version string) ([]string, error) {
+				advisories, err := client.Check([]string{version})
+				if err != nil {
+					return nil, err
+				}
+				ids := make([]string, 0, len(advisories[version]))
+				for _, adv := range advisories[version] {
+					ids = append(ids, adv.ID)
+				}
+				return ids, nil
+			}
+		}
+	}
+	return facts
+}
+
+// toolchainVersion returns Go version of toolchain in goRoot, or name of
+// its directory if version is unknown. Symlink of current version is followed once.
+func toolchainVersion(goRoot string) string {
+	if v, err := toolchain.ReadVersion(goRoot); err == nil && v != "" {
+		return v
+	}
+	if target, err := os.Readlink(goRoot); err == nil {
+		return filepath.Base(target)
+	}
+	return filepath.Base(goRoot)
+}
+
+func checkPolicies(policies []*policy.Policy, version string, facts policy.Facts) error {
+	for _, p := range policies {
+		if err := p.Check(version, facts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforcePolicy returns error if version is forbidden by policies in effect.
+// With --ignore-policy violation is logged and printed as a warning instead.
+func enforcePolicy(command, version string, online bool) error {
+	policies, err := loadPolicies()
+	if err != nil {
+		return fmt.Errorf("load policy: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	version = "go" + strings.TrimPrefix(version, "go")
+	err = checkPolicies(policies, version, policyFacts(policies, online))
+	var v *policy.Violation
+	if !errors.As(err, &v) || !ignorePolicy {
+		return err
+	}
+
+	file, err := sys.PolicyLogPath()
+	if err != nil {
+		return fmt.Errorf("determine policy log path: %w", err)
+	}
+	if err := policy.LogOverride(file, command, v); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, sPadLeft.Render(sWarningText.Render(fmt.Sprintf("Ignoring policy: %s (logged to %s)", v, file))))
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/proxy"
+	"github.com/x-dvr/gm/sys"
+)
+
+var (
+	proxyAddr     string
+	proxyUpstream string
+)
+
+// proxyCmd represents the proxy command
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Serve installed toolchains to the go command",
+}
+
+// proxyServeCmd represents the proxy serve command
+var proxyServeCmd = &cobra.Command{
+	Use:   "serve",
+	Args:  cobra.ExactArgs(0),
+	Short: "Start GOPROXY serving installed toolchains",
+	Long: `Start module proxy serving golang.org/toolchain module from installed toolchains,
+so GOTOOLCHAIN switching uses them instead of downloading.
+Requests for other modules and for toolchains which are not installed
+are passed to upstream proxy.
+
+Point GOPROXY at the proxy with:
+	eval $(gm env --proxy)`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cacheDir, err := sys.ProxyCachePath()
+		if err != nil {
+			printError("Failed to determine proxy cache path: %s", err)
+			os.Exit(1)
+		}
+		logger := log.New(os.Stderr, "", log.LstdFlags)
+		srv, err := proxy.New(proxy.Config{
+			Toolchains: sys.ListInstalledVersions,
+			CacheDir:   cacheDir,
+			Upstream:   proxyUpstream,
+			ErrorLog:   logger,
+		})
+		if err != nil {
+			printError("Failed to start proxy: %s", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(sInfo.Render(fmt.Sprintf("Serving installed toolchains on http://%s", proxyAddr)))
+		if err := http.ListenAndServe(proxyAddr, srv); err != nil {
+			printError("Failed to serve: %s", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	proxyServeCmd.Flags().StringVar(&proxyAddr, "addr", proxy.DefaultAddr, "Address to listen on")
+	proxyServeCmd.Flags().StringVar(&proxyUpstream, "upstream", proxy.DefaultUpstream, "Module proxy for other requests (\"off\" to disable)")
+	proxyCmd.AddCommand(proxyServeCmd)
+	rootCmd.AddCommand(proxyCmd)
+}
@@ -0,0 +1,279 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/progress"
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var (
+	pruneKeep        int
+	pruneUnsupported bool
+	pruneUnusedFor   string
+	pruneProjects    []string
+	pruneDryRun      bool
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Args:  cobra.ExactArgs(0),
+	Short: "Remove installed versions of Go toolchain selected by retention policies",
+	Long: `Remove installed versions of Go toolchain selected by retention policies.
+Version is removed when any of the given policies selects it:
+	--keep N          keep only N newest versions of each minor line
+	--unsupported     remove versions of minor lines no longer supported
+	--unused-for AGE  remove versions not used for AGE (e.g. 720h, 30d or 8w)
+Disk quota from config is applied as well, least recently used versions
+are removed until installed toolchains fit it.
+
+Current version, versions pinned by projects listed in config or given
+with --project (.go-version, .tool-versions, toolchain directive of go.mod)
+and external toolchains are never removed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if pruneKeep < 0 {
+			printError("Keep must not be negative")
+			os.Exit(1)
+		}
+		unusedFor, err := parseAge(pruneUnusedFor)
+		if err != nil {
+			printError("Invalid --unused-for: %s", err)
+			os.Exit(1)
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			printError("Failed to load config: %s", err)
+			os.Exit(1)
+		}
+		quota, err := cfg.QuotaBytes()
+		if err != nil {
+			printError("Invalid config: %s", err)
+			os.Exit(1)
+		}
+		if pruneKeep == 0 && !pruneUnsupported && unusedFor == 0 && quota == 0 {
+			printError("Specify at least one policy: --keep, --unsupported or --unused-for")
+			os.Exit(1)
+		}
+
+		installed, err := sys.ListInstalledVersions()
+		if err != nil {
+			printError("Failed to list installed versions: %s", err)
+			os.Exit(1)
+		}
+		current, err := sys.GetCurrentVersion()
+		if err != nil {
+			printError("Failed to determine current version: %s", err)
+			os.Exit(1)
+		}
+		keep, err := pinnedVersions(append(cfg.Projects, pruneProjects...))
+		if err != nil {
+			printError("Failed to read pinned versions: %s", err)
+			os.Exit(1)
+		}
+
+		policy := sys.PrunePolicy{
+			KeepPatches: pruneKeep,
+			Unsupported: pruneUnsupported,
+			UnusedFor:   unusedFor,
+			Now:         time.Now(),
+			Keep:        keep,
+		}
+		if pruneUnsupported {
+			policy.Catalog, err = loadCatalog()
+			if err != nil {
+				printError("Failed to load release catalog: %s", err)
+				os.Exit(1)
+			}
+		}
+		sizes, err := diskUsage(installed)
+		if err != nil {
+			printError("Failed to compute disk usage: %s", err)
+			os.Exit(1)
+		}
+
+		items := policy.Select(installed, current)
+		if quota > 0 {
+			items = append(items, sys.SelectOverQuota(remaining(installed, items), current, keep, sizes, quota)...)
+		}
+		if len(items) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("Nothing to prune")))
+			return
+		}
+
+		var reclaimed int64
+		failed := false
+		lines := make([]string, 0, len(items)+1)
+		for _, item := range items {
+			tc := item.Toolchain
+			text := fmt.Sprintf("%s - %s (%s)", tc.Version, item.Reason, formatSize(sizes[tc.Version]))
+			if pruneDryRun {
+				lines = append(lines, sText.Render(text))
+				reclaimed += sizes[tc.Version]
+				continue
+			}
+			if _, err := sys.Uninstall("go" + tc.Version); err != nil {
+				failed = true
+				lines = append(lines, sErrorText.Render(fmt.Sprintf("%s - failed to remove: %s", tc.Version, err)))
+				continue
+			}
+			lines = append(lines, sText.Render("removed "+text))
+			reclaimed += sizes[tc.Version]
+		}
+		summary := "Reclaimed " + formatSize(reclaimed)
+		if pruneDryRun {
+			summary = fmt.Sprintf("Would remove %d version(s) and reclaim %s", len(items), formatSize(reclaimed))
+		}
+		lines = append(lines, sActiveText.Render(summary))
+		fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	pruneCmd.Flags().IntVar(&pruneKeep, "keep", 0, "Keep only N newest versions of each minor line")
+	pruneCmd.Flags().BoolVar(&pruneUnsupported, "unsupported", false, "Remove versions of unsupported minor lines")
+	pruneCmd.Flags().StringVar(&pruneUnusedFor, "unused-for", "", "Remove versions not used for the given time (e.g. 30d)")
+	pruneCmd.Flags().StringArrayVar(&pruneProjects, "project", nil, "Keep versions pinned by project in the given directory")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only show what would be removed")
+	rootCmd.AddCommand(pruneCmd)
+}
+
+// parseAge parses duration which may be given in days (d) or weeks (w).
+func parseAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// pinnedVersions returns versions pinned by projects in dirs.
+func pinnedVersions(dirs []string) ([]string, error) {
+	var pinned []string
+	for _, dir := range dirs {
+		versions, err := project.Pinned(dir)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", dir, err)
+		}
+		pinned = append(pinned, versions...)
+	}
+	return pinned, nil
+}
+
+// diskUsage returns sizes of toolchains managed by gm keyed by version.
+func diskUsage(installed []sys.Toolchain) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(installed))
+	for _, tc := range installed {
+		if tc.External {
+			continue
+		}
+		size, err := toolchain.DiskUsage(tc.Path)
+		if err != nil {
+			return nil, err
+		}
+		sizes[tc.Version] = size
+	}
+	return sizes, nil
+}
+
+// remaining returns installed toolchains not selected for removal.
+func remaining(installed []sys.Toolchain, items []sys.PruneItem) []sys.Toolchain {
+	selected := make(map[string]bool, len(items))
+	for _, item := range items {
+		selected[item.Toolchain.Version] = true
+	}
+	var rest []sys.Toolchain
+	for _, tc := range installed {
+		if !selected[tc.Version] {
+			rest = append(rest, tc)
+		}
+	}
+	return rest
+}
+
+// enforceQuota removes least recently used toolchains when installed toolchains
+// do not fit disk quota from config. Versions pinned by projects and the given
+// versions, e.g. installed just now, are kept.
+func enforceQuota(tracker progress.IOTracker, keepVersions ...string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	quota, err := cfg.QuotaBytes()
+	if err != nil || quota == 0 {
+		return err
+	}
+
+	installed, err := sys.ListInstalledVersions()
+	if err != nil {
+		return fmt.Errorf("list installed versions: %w", err)
+	}
+	current, err := sys.GetCurrentVersion()
+	if err != nil {
+		return fmt.Errorf("determine current version: %w", err)
+	}
+	keep, err := pinnedVersions(cfg.Projects)
+	if err != nil {
+		return err
+	}
+	keep = append(keep, keepVersions...)
+	// versions pinned and locked by project in working directory are kept as well
+	if pin, err := project.Find("."); err == nil && pin != nil {
+		keep = append(keep, pin.Version)
+	}
+	if lock, err := project.FindLock("."); err == nil && lock != nil {
+		keep = append(keep, lock.Versions()...)
+	}
+	sizes, err := diskUsage(installed)
+	if err != nil {
+		return fmt.Errorf("compute disk usage: %w", err)
+	}
+
+	items := sys.SelectOverQuota(installed, current, keep, sizes, quota)
+	if len(items) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, item := range items {
+		tracker.Reset(fmt.Sprintf("Removing %s to fit disk quota of %s ...", item.Toolchain.Version, cfg.Quota))
+		if _, err := sys.Uninstall("go" + item.Toolchain.Version); err != nil {
+			errs = append(errs, fmt.Errorf("remove %s: %w", item.Toolchain.Version, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	tracker.Reset(fmt.Sprintf("Removed %d least recently used version(s) to fit disk quota of %s", len(items), cfg.Quota))
+	return nil
+}
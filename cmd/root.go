@@ -4,13 +4,18 @@ Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/x-dvr/gm/config"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
 	"github.com/x-dvr/gm/ui"
 )
 
@@ -67,6 +72,68 @@ func printError(fstr string, args ...any) {
 	fmt.Fprintln(os.Stderr, out)
 }
 
+// isTerminal reports whether standard output is a terminal.
+func isTerminal() bool {
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// loadCatalog returns catalog of published Go releases, refreshed once a day.
+func loadCatalog() (*toolchain.Catalog, error) {
+	cacheFile, err := sys.CatalogPath()
+	if err != nil {
+		return nil, fmt.Errorf("determine release catalog path: %w", err)
+	}
+	sumdbCache, err := sys.SumDBCachePath()
+	if err != nil {
+		return nil, fmt.Errorf("determine checksum database cache path: %w", err)
+	}
+	return toolchain.LoadCatalog(cacheFile, toolchain.CatalogMaxAge, toolchain.ProxyConfigFromEnv(sumdbCache))
+}
+
+// loadConfig reads gm configuration.
+func loadConfig() (*config.Config, error) {
+	file, err := sys.ConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("determine config path: %w", err)
+	}
+	return config.Load(file)
+}
+
+// unsupportedText describes lifecycle status of unsupported version.
+func unsupportedText(since string) string {
+	if since == "" {
+		return "unsupported, no longer receives security fixes"
+	}
+	return fmt.Sprintf("unsupported since %s release, no longer receives security fixes", strings.TrimPrefix(since, "go"))
+}
+
+// warnUnsupported prints warning to stderr if version is no longer supported according to catalog.
+func warnUnsupported(version string, catalog *toolchain.Catalog) {
+	st := catalog.Status(version)
+	if !st.Unsupported {
+		return
+	}
+	msg := fmt.Sprintf("Go %s is %s", strings.TrimPrefix(version, "go"), unsupportedText(st.UnsupportedSince))
+	fmt.Fprintln(os.Stderr, sPadLeft.Render(sWarningText.Render(msg)))
+}
+
+// confirm asks user a yes/no question, answer is negative
+// when standard input is not a terminal.
+func confirm(question string) bool {
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	fmt.Print(sInfo.Render(question + " [y/N] "))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
 var (
 	theme     = ui.Catppuccin{}
 	sTitleBar = lipgloss.NewStyle().Padding(1, 0, 1, 2)
@@ -88,10 +155,14 @@ var (
 			BorderForeground(theme.Accent()).
 			Padding(0, 0, 0, 1).
 			Margin(0, 0, 1)
-	sText       = lipgloss.NewStyle().Foreground(theme.Subdued(4))
-	sActiveText = lipgloss.NewStyle().Foreground(theme.Accent())
-	sSubtext    = lipgloss.NewStyle().Foreground(theme.Surface(2))
-	sInfo       = lipgloss.NewStyle().
+	sText        = lipgloss.NewStyle().Foreground(theme.Subdued(4))
+	sActiveText  = lipgloss.NewStyle().Foreground(theme.Accent())
+	sSubtext     = lipgloss.NewStyle().Foreground(theme.Surface(2))
+	sGroupTitle  = lipgloss.NewStyle().Bold(true).Foreground(theme.Text()).Margin(0, 0, 1)
+	sErrorText   = lipgloss.NewStyle().Foreground(theme.Error())
+	sInfoText    = lipgloss.NewStyle().Foreground(theme.Info())
+	sWarningText = lipgloss.NewStyle().Foreground(theme.Warning())
+	sInfo        = lipgloss.NewStyle().
 			Padding(0, 0, 0, 2).
 			Foreground(theme.Info())
 	sError = lipgloss.NewStyle().
@@ -0,0 +1,153 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+var scanInstallMissing bool
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan [dir...]",
+	Short: "Find Go versions required by repositories in directory trees",
+	Long: `Walk directory trees (working directory by default) and report Go versions
+required by repositories: go and toolchain directives of go.mod and go.work,
+.go-version and .tool-versions files. Hidden directories, vendor,
+node_modules and testdata are skipped.
+
+Versions of toolchain directives and version files must be installed exactly.
+Go directives are satisfied by the newest installed release when it is not older.
+Installed versions no repository requires are reported as well.
+
+Use --install-missing to install all missing versions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		roots := args
+		if len(roots) == 0 {
+			roots = []string{"."}
+		}
+		res, err := project.Scan(roots)
+		if err != nil {
+			printError("Failed to scan: %s", err)
+			os.Exit(1)
+		}
+		installed, err := sys.ListInstalledVersions()
+		if err != nil {
+			printError("Failed to list installed versions: %s", err)
+			os.Exit(1)
+		}
+		// newest patch releases are installed for missing go directives when catalog is available
+		catalog, _ := loadCatalog()
+		required, unneeded := sys.CheckRequirements(installed, res.Requirements, catalog)
+
+		var missing []string
+		lines := []string{sGroupTitle.Render("Required versions")}
+		if len(required) == 0 {
+			lines = append(lines, sSubtext.Render("none"))
+		}
+		for _, rv := range required {
+			lines = append(lines, requiredLine(rv), sSubtext.Render("  "+strings.Join(rv.Files, "\n  ")))
+			if rv.Install != "" && !slices.Contains(missing, rv.Install) {
+				missing = append(missing, rv.Install)
+			}
+		}
+		if len(unneeded) > 0 {
+			lines = append(lines, sGroupTitle.Render("Installed, not required"))
+			for _, tc := range unneeded {
+				lines = append(lines, sText.Render(tc.Version))
+			}
+		}
+		if len(res.Problems) > 0 {
+			lines = append(lines, sGroupTitle.Render("Problems"))
+			for _, p := range res.Problems {
+				lines = append(lines, sErrorText.Render(p.Error()))
+			}
+		}
+		fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+
+		if !scanInstallMissing || len(missing) == 0 {
+			return
+		}
+		for _, v := range missing {
+			if err := enforcePolicy("scan", v, true); err != nil {
+				printError("%s", err)
+				os.Exit(1)
+			}
+		}
+		installMissing(missing)
+	},
+}
+
+func init() {
+	scanCmd.Flags().BoolVar(&scanInstallMissing, "install-missing", false, "Install missing versions")
+	addIgnorePolicyFlag(scanCmd)
+	rootCmd.AddCommand(scanCmd)
+}
+
+// requiredLine describes required version and toolchain satisfying it.
+func requiredLine(rv sys.RequiredVersion) string {
+	text := strings.TrimPrefix(rv.Version, "go")
+	if rv.Minimum {
+		text = ">= " + text
+	}
+	switch {
+	case rv.Installed == nil && rv.Install != rv.Version:
+		return sWarningText.Render(fmt.Sprintf("%s - missing, %s would be installed", text, strings.TrimPrefix(rv.Install, "go")))
+	case rv.Installed == nil:
+		return sWarningText.Render(text + " - missing")
+	case rv.Minimum:
+		return sActiveText.Render(fmt.Sprintf("%s - satisfied by %s", text, rv.Installed.Version))
+	default:
+		return sActiveText.Render(text + " - installed")
+	}
+}
+
+// installMissing installs releases one after another, failed installations do not stop the rest.
+func installMissing(versions []string) {
+	tui := pbar.New(fmt.Sprintf("Installing %d missing version(s)", len(versions)))
+
+	go func() {
+		tracker := tui.GetTracker()
+		var errs []error
+		var installed []string
+		for _, v := range versions {
+			destPath, err := sys.PathForVersion(v)
+			if err == nil {
+				err = installRelease(v, destPath, toolchain.SourceAuto, tracker)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("install %s: %w", strings.TrimPrefix(v, "go"), err))
+				continue
+			}
+			installed = append(installed, v)
+		}
+		// versions installed just now must not be removed to fit quota
+		if err := enforceQuota(tracker, installed...); err != nil {
+			errs = append(errs, fmt.Errorf("enforce disk quota: %w", err))
+		}
+		if err := errors.Join(errs...); err != nil {
+			tui.Exit(err)
+			return
+		}
+		tui.SetInfo(fmt.Sprintf("Installed %d version(s)", len(versions)))
+		tui.Exit(nil)
+	}()
+
+	if err := tui.Run(); err != nil {
+		os.Exit(1)
+	}
+}
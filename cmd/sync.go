@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/progress"
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Args:  cobra.ExactArgs(0),
+	Short: "Install Go versions listed in gm.lock",
+	Long: fmt.Sprintf(`Install Go versions listed in %s of repository in working directory
+or its parents. Release archives are downloaded from dl.google.com and must
+match checksums in lock file, installed versions must have been installed
+from archives with the same checksums. Command fails on any mismatch.
+
+Use 'gm lock' to generate lock file.`, project.LockFile),
+	Run: func(cmd *cobra.Command, args []string) {
+		lock, err := project.FindLock(".")
+		if err != nil {
+			printError("Failed to read %s: %s", project.LockFile, err)
+			os.Exit(1)
+		}
+		if lock == nil {
+			printError("No %s found, run 'gm lock <version>...' to create it", project.LockFile)
+			os.Exit(1)
+		}
+		versions := lock.Versions()
+		if len(versions) == 0 {
+			printError("%s lists no versions", project.LockFile)
+			os.Exit(1)
+		}
+
+		for _, v := range versions {
+			if err := enforcePolicy("sync", v, true); err != nil {
+				printError("%s", err)
+				os.Exit(1)
+			}
+		}
+
+		tui := pbar.New(fmt.Sprintf("Syncing %d version(s) from %s", len(versions), lock.Dir))
+
+		go func() {
+			tracker := tui.GetTracker()
+			var errs []error
+			installed := 0
+			for _, v := range versions {
+				ok, err := syncVersion(lock, v, tracker)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", strings.TrimPrefix(v, "go"), err))
+				} else if ok {
+					installed++
+				}
+			}
+			if err := errors.Join(errs...); err != nil {
+				tui.Exit(err)
+				return
+			}
+			// locked versions of working directory are kept by quota
+			if err := enforceQuota(tracker, versions...); err != nil {
+				tui.Exit(fmt.Errorf("enforce disk quota: %w", err))
+				return
+			}
+			tui.SetInfo(fmt.Sprintf("%d version(s) match %s, %d installed", len(versions), project.LockFile, installed))
+			tui.Exit(nil)
+		}()
+
+		if err := tui.Run(); err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	addIgnorePolicyFlag(syncCmd)
+	rootCmd.AddCommand(syncCmd)
+}
+
+// syncVersion installs locked version unless it is installed already and reports whether it was installed.
+// Installed version must come from archive with locked checksum.
+func syncVersion(lock *project.Lock, version string, tracker progress.IOTracker) (bool, error) {
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	want, ok := lock.Checksum(version, platform)
+	if !ok {
+		return false, fmt.Errorf("%s has no checksum for %s, run 'gm lock --platform %s'", project.LockFile, platform, platform)
+	}
+
+	installed, err := sys.ListInstalledVersions()
+	if err != nil {
+		return false, fmt.Errorf("list installed versions: %w", err)
+	}
+	for _, tc := range installed {
+		if "go"+tc.Version == version && tc.External {
+			return false, fmt.Errorf("registered external toolchain %s can not be verified against %s", tc.Path, project.LockFile)
+		}
+	}
+
+	destPath, err := sys.PathForVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("determine destination path for installation: %w", err)
+	}
+	if toolchain.IsInstalled(destPath) {
+		rec, err := toolchain.ReadRecord(destPath)
+		if err != nil {
+			return false, fmt.Errorf("read install record: %w", err)
+		}
+		if rec.SHA256 != want {
+			return false, fmt.Errorf("%w: installed from %s with checksum %q, %s expects %s, reinstall it with 'gm uninstall %s && gm sync'",
+				toolchain.ErrChecksumMismatch, rec.Source, rec.SHA256, project.LockFile, want, strings.TrimPrefix(version, "go"))
+		}
+		tracker.Reset(fmt.Sprintf("Version %s matches %s", strings.TrimPrefix(version, "go"), project.LockFile))
+		return false, nil
+	}
+	if err := toolchain.InstallLocked(version, destPath, want, tracker); err != nil {
+		return false, err
+	}
+	return true, nil
+}
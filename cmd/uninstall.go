@@ -0,0 +1,55 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/x-dvr/gm/sys"
+)
+
+// uninstallCmd represents the uninstall command
+var uninstallCmd = &cobra.Command{
+	Use:     "uninstall",
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	Short:   "Remove specified version of Go toolchain",
+	Long:    "Remove specified version of Go toolchain. External toolchains are only unregistered, their files are kept.",
+	Run: func(cmd *cobra.Command, args []string) {
+		version := args[0]
+		if !strings.HasPrefix(version, "go") {
+			version = "go" + version
+		}
+		unprefixed := strings.TrimPrefix(version, "go")
+
+		tc, err := sys.Uninstall(version)
+		if err != nil {
+			switch {
+			case errors.Is(err, sys.ErrNotInstalled):
+				printError("Version %s is not installed", unprefixed)
+			case errors.Is(err, sys.ErrInvalidName):
+				printError("Invalid version %q", args[0])
+			case errors.Is(err, sys.ErrIsCurrent):
+				printError("Version %s is current, switch to another version first", unprefixed)
+			default:
+				printError("Failed to uninstall version %s: %s", unprefixed, err)
+			}
+			os.Exit(1)
+		}
+
+		if tc.External {
+			fmt.Println(sInfo.Render(fmt.Sprintf("Unregistered %s, files at %s are kept", unprefixed, tc.Path)))
+			return
+		}
+		fmt.Println(sInfo.Render(fmt.Sprintf("Removed %s", unprefixed)))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uninstallCmd)
+}
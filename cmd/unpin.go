@@ -0,0 +1,51 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/project"
+)
+
+// unpinCmd represents the unpin command
+var unpinCmd = &cobra.Command{
+	Use:   "unpin",
+	Args:  cobra.ExactArgs(0),
+	Short: "Remove pinned version of Go toolchain from project in working directory",
+	Long: `Remove pinned version of Go toolchain from project in working directory.
+Pins in all formats are removed: .go-version file, golang entry of
+.tool-versions and toolchain directive of go.mod. Other content of
+.tool-versions and go.mod is kept.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := os.Getwd()
+		if err != nil {
+			printError("Failed to determine working directory: %s", err)
+			os.Exit(1)
+		}
+		removed, err := project.Remove(dir)
+		lines := make([]string, 0, len(removed))
+		for _, f := range removed {
+			lines = append(lines, sText.Render("removed pin from "+f.File()))
+		}
+		if len(lines) > 0 {
+			fmt.Println(sPadLeft.Render(sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))))
+		}
+		if err != nil {
+			printError("Failed to remove pin: %s", err)
+			os.Exit(1)
+		}
+		if len(removed) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("Project in working directory pins no version")))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unpinCmd)
+}
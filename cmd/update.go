@@ -0,0 +1,142 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/progress"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+var (
+	updateMinor     string
+	updateRemoveOld bool
+)
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Args:  cobra.ExactArgs(0),
+	Short: "Install the newest patch release of every installed minor line of Go",
+	Long: `Install the newest patch release of every installed minor line of Go,
+or only of the line given with --minor (e.g. --minor 1.22).
+Current version is moved to the new release of its line.
+
+Use --remove-old to remove superseded versions of the line after successful install.
+Versions pinned by projects listed in config are kept.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		updates, err := findUpdates()
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		if updateMinor != "" {
+			lang, err := gover.Parse(updateMinor)
+			if err != nil {
+				printError("Invalid --minor: %s", err)
+				os.Exit(1)
+			}
+			updates = slices.DeleteFunc(updates, func(u sys.Update) bool { return u.Lang != lang.Lang() })
+		}
+		if len(updates) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("All installed versions are up to date")))
+			return
+		}
+
+		for _, u := range updates {
+			if err := enforcePolicy("update", u.Latest, true); err != nil {
+				printError("%s", err)
+				os.Exit(1)
+			}
+		}
+
+		tui := pbar.New(fmt.Sprintf("Updating %d minor line(s) of Go", len(updates)))
+		go func() {
+			tracker := tui.GetTracker()
+			var errs []error
+			var installed []string
+			for _, u := range updates {
+				if err := applyUpdate(u, tracker); err != nil {
+					errs = append(errs, fmt.Errorf("update %s: %w", u.Lang, err))
+					continue
+				}
+				installed = append(installed, u.Latest)
+			}
+			// versions installed just now must not be removed to fit quota
+			if err := enforceQuota(tracker, installed...); err != nil {
+				errs = append(errs, fmt.Errorf("enforce disk quota: %w", err))
+			}
+			if len(errs) == 0 {
+				tui.SetInfo(fmt.Sprintf("Successfully updated %d minor line(s)", len(updates)))
+			}
+			tui.Exit(errors.Join(errs...))
+		}()
+		if err := tui.Run(); err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	updateCmd.Flags().StringVar(&updateMinor, "minor", "", "Update only the given minor line (e.g. 1.22)")
+	updateCmd.Flags().BoolVar(&updateRemoveOld, "remove-old", false, "Remove superseded versions after successful install")
+	addIgnorePolicyFlag(updateCmd)
+	rootCmd.AddCommand(updateCmd)
+}
+
+// applyUpdate installs the newest release of minor line, moves current version to it
+// and removes superseded versions if requested.
+func applyUpdate(u sys.Update, tracker progress.IOTracker) error {
+	destPath, err := sys.PathForVersion(u.Latest)
+	if err != nil {
+		return fmt.Errorf("determine destination path for installation: %w", err)
+	}
+	if err := installRelease(u.Latest, destPath, toolchain.SourceAuto, tracker); err != nil {
+		return fmt.Errorf("install %s: %w", strings.TrimPrefix(u.Latest, "go"), err)
+	}
+
+	current, err := sys.GetCurrentVersion()
+	if err != nil {
+		return fmt.Errorf("determine current version: %w", err)
+	}
+	isCurrent := func(tc sys.Toolchain) bool { return tc.Version == current.Version }
+	if current != nil && slices.ContainsFunc(u.Superseded, isCurrent) {
+		if err := sys.SetAsCurrent(u.Latest); err != nil {
+			return fmt.Errorf("set %s as current: %w", strings.TrimPrefix(u.Latest, "go"), err)
+		}
+	}
+	if !updateRemoveOld {
+		return nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	pinned, err := pinnedVersions(cfg.Projects)
+	if err != nil {
+		return err
+	}
+	for _, tc := range u.Superseded {
+		if slices.Contains(pinned, "go"+tc.Version) {
+			tracker.Reset(fmt.Sprintf("Keeping %s, it is pinned by project", tc.Version))
+			continue
+		}
+		tracker.Reset(fmt.Sprintf("Removing %s ...", tc.Version))
+		if _, err := sys.Uninstall("go" + tc.Version); err != nil {
+			return fmt.Errorf("remove %s: %w", tc.Version, err)
+		}
+	}
+	return nil
+}
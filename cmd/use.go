@@ -4,12 +4,12 @@ Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/x-dvr/gm/sys"
-	"github.com/x-dvr/gm/toolchain"
 )
 
 // useCmd represents the use command
@@ -18,26 +18,59 @@ var useCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Short: "Set specified version of Go toolchain as current",
 	Run: func(cmd *cobra.Command, args []string) {
-		var err error
 		version := args[0]
 		if version == versionLatest {
-			version, err = toolchain.GetLatestVersion()
+			latest, err := sys.LatestInstalled()
 			if err != nil {
-				printError("Failed to get latest Go version: %s", err)
+				printError("Failed to find latest installed Go version: %s", err)
 				os.Exit(1)
 			}
+			version = latest.Version
 		}
 		if !strings.HasPrefix(version, "go") {
 			version = "go" + version
 		}
 
+		if goRoot, err := sys.GoRoot(version); err == nil {
+			if err := enforcePolicy("use", toolchainVersion(goRoot), true); err != nil {
+				printError("%s", err)
+				os.Exit(1)
+			}
+		}
+
 		if err := sys.SetAsCurrent(version); err != nil {
 			printError("Failed to set current version: %s", err)
 			os.Exit(1)
 		}
+		if catalog, err := loadCatalog(); err == nil {
+			warnUnsupported(version, catalog)
+		}
+		warnVulnerable(version)
 	},
 }
 
 func init() {
+	addIgnorePolicyFlag(useCmd)
 	rootCmd.AddCommand(useCmd)
 }
+
+// warnVulnerable warns about known vulnerabilities of version found in
+// vulnerability database cached by previous audits. Nothing is fetched over network.
+func warnVulnerable(version string) {
+	current, err := sys.GetCurrentVersion()
+	if err != nil || current == nil {
+		return
+	}
+	client, err := vulnClient(true)
+	if err != nil {
+		return
+	}
+	exact := exactVersion(*current)
+	advisories, err := client.Check([]string{exact})
+	if err != nil || len(advisories[exact]) == 0 {
+		return
+	}
+	fmt.Println(sPadLeft.Render(sInfo.Render(fmt.Sprintf(
+		"Version %s has %d known vulnerabilities, run 'gm audit' for details",
+		strings.TrimPrefix(version, "go"), len(advisories[exact])))))
+}
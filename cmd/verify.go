@@ -0,0 +1,177 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+	"github.com/x-dvr/gm/ui/pbar"
+)
+
+var (
+	verifyAll    bool
+	verifyRepair bool
+)
+
+// verifyResult is the outcome of verification of a single toolchain.
+type verifyResult struct {
+	tc       sys.Toolchain
+	report   *toolchain.Report
+	err      error
+	repaired []string
+}
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [version]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Check installed toolchains for modified, missing or extra files",
+	Long: `Compare files of installed toolchain with manifest recorded at install time.
+Current version is verified if no version is given, use --all to verify all installed versions.
+
+Use --repair to restore missing and modified files from the archive toolchain was installed from.
+Extra files are only reported.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if verifyAll && len(args) > 0 {
+			printError("Specify either version or --all")
+			os.Exit(1)
+		}
+		toolchains, err := verifyTargets(args)
+		if err != nil {
+			printError("%s", err)
+			os.Exit(1)
+		}
+		if len(toolchains) == 0 {
+			fmt.Println(sPadLeft.Render(sInfo.Render("No Go versions found")))
+			return
+		}
+
+		results := make([]verifyResult, 0, len(toolchains))
+		tui := pbar.New(fmt.Sprintf("Verifying %d toolchain(s)", len(toolchains)))
+		go func() {
+			tracker := tui.GetTracker()
+			for _, tc := range toolchains {
+				tracker.Reset(fmt.Sprintf("Verifying %s ...", tc.Version))
+				res := verifyResult{tc: tc}
+				res.report, res.err = toolchain.VerifyTree(tc.Path)
+				if res.err == nil && verifyRepair && len(res.report.Damaged()) > 0 {
+					damaged := res.report.Damaged()
+					if err := toolchain.Repair(tc.Path, damaged, tracker); err != nil {
+						res.err = fmt.Errorf("repair: %w", err)
+					} else {
+						res.repaired = damaged
+						res.report, res.err = toolchain.VerifyTree(tc.Path)
+					}
+				}
+				results = append(results, res)
+			}
+			tui.Exit(nil)
+		}()
+		if err := tui.Run(); err != nil {
+			os.Exit(1)
+		}
+
+		if !printVerifyResults(results) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyAll, "all", false, "Verify all installed versions")
+	verifyCmd.Flags().BoolVar(&verifyRepair, "repair", false, "Restore damaged files from cached archive")
+	rootCmd.AddCommand(verifyCmd)
+}
+
+// verifyTargets returns toolchains selected for verification.
+func verifyTargets(args []string) ([]sys.Toolchain, error) {
+	installed, err := sys.ListInstalledVersions()
+	if err != nil {
+		return nil, fmt.Errorf("list installed versions: %w", err)
+	}
+	if verifyAll {
+		var managed []sys.Toolchain
+		for _, tc := range installed {
+			// files of external toolchains are not managed by gm
+			if !tc.External {
+				managed = append(managed, tc)
+			}
+		}
+		return managed, nil
+	}
+
+	var version string
+	if len(args) == 1 {
+		version = strings.TrimPrefix(args[0], "go")
+	} else {
+		current, err := sys.GetCurrentVersion()
+		if err != nil {
+			return nil, fmt.Errorf("determine current version: %w", err)
+		}
+		if current == nil {
+			return nil, errors.New("no current version, specify version to verify")
+		}
+		version = current.Version
+	}
+	for _, tc := range installed {
+		if tc.Version != version {
+			continue
+		}
+		if tc.External {
+			return nil, fmt.Errorf("version %s is external, its files are not managed by gm", version)
+		}
+		return []sys.Toolchain{tc}, nil
+	}
+	return nil, fmt.Errorf("version %s is not installed", version)
+}
+
+// printVerifyResults outputs verification results and reports whether all toolchains are intact.
+func printVerifyResults(results []verifyResult) bool {
+	ok := true
+	items := make([]string, 0, len(results))
+	for _, res := range results {
+		var lines []string
+		switch {
+		case errors.Is(res.err, toolchain.ErrNoManifest):
+			ok = false
+			lines = append(lines, sText.Render(res.tc.Version+" - no manifest, installed by older version of gm"))
+		case res.err != nil:
+			ok = false
+			lines = append(lines, sErrorText.Render(fmt.Sprintf("%s - %s", res.tc.Version, res.err)))
+		case res.report.OK():
+			label := res.tc.Version + " - ok"
+			if len(res.repaired) > 0 {
+				label = fmt.Sprintf("%s - repaired %d file(s)", res.tc.Version, len(res.repaired))
+			}
+			lines = append(lines, sActiveText.Render(label))
+		default:
+			ok = false
+			label := res.tc.Version
+			if len(res.repaired) > 0 {
+				label = fmt.Sprintf("%s - repaired %d file(s)", res.tc.Version, len(res.repaired))
+			}
+			lines = append(lines, sText.Render(label))
+			for _, f := range res.report.Missing {
+				lines = append(lines, sErrorText.Render("missing:  "+f))
+			}
+			for _, f := range res.report.Modified {
+				lines = append(lines, sErrorText.Render("modified: "+f))
+			}
+			for _, f := range res.report.Extra {
+				lines = append(lines, sSubtext.Render("extra:    "+f))
+			}
+		}
+		items = append(items, sListItem.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)))
+	}
+	fmt.Println(sPadLeft.Render(lipgloss.JoinVertical(lipgloss.Left, items...)))
+	return ok
+}
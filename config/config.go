@@ -0,0 +1,85 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidSize = errors.New("invalid size")
+
+// Config holds user settings of gm.
+type Config struct {
+	// Quota limits total disk usage of installed toolchains, e.g. "10GiB".
+	// Least recently used versions are removed after install when it is exceeded.
+	Quota string `json:"quota,omitempty"`
+	// Projects are directories whose pinned versions are never pruned
+	Projects []string `json:"projects,omitempty"`
+	// VulnDB is URL of Go vulnerability database, file:// URL selects local copy
+	VulnDB string `json:"vulndb,omitempty"`
+	// PinFormat is the default format of "gm pin": go-version, tool-versions or go.mod
+	PinFormat string `json:"pin_format,omitempty"`
+	// Policy is a path of organisation policy file applied in addition to policy of repository
+	Policy string `json:"policy,omitempty"`
+}
+
+// Load reads configuration from file. Missing file results in empty configuration.
+func Load(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("decode config %s: %w", file, err)
+	}
+	return &cfg, nil
+}
+
+// QuotaBytes returns disk quota in bytes, 0 if quota is not set.
+func (c *Config) QuotaBytes() (int64, error) {
+	if c.Quota == "" {
+		return 0, nil
+	}
+	size, err := ParseSize(c.Quota)
+	if err != nil {
+		return 0, fmt.Errorf("quota: %w", err)
+	}
+	return size, nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	mult   int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"T", 1 << 40},
+	{"B", 1},
+}
+
+// ParseSize parses size in bytes with optional unit, e.g. "512MiB", "10GB" or "2G".
+// Single letter units are binary.
+func ParseSize(s string) (int64, error) {
+	num, mult := strings.TrimSpace(s), int64(1)
+	for _, u := range sizeUnits {
+		if n, ok := strings.CutSuffix(num, u.suffix); ok {
+			num, mult = strings.TrimSpace(n), u.mult
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("%w %q", ErrInvalidSize, s)
+	}
+	return int64(f * float64(mult)), nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	cfg, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load (no file): %v", err)
+	}
+	if cfg.Quota != "" || len(cfg.Projects) != 0 {
+		t.Errorf("cfg = %+v, want empty config", cfg)
+	}
+
+	if err := os.WriteFile(file, []byte(`{"quota": "2GiB", "projects": ["/src/app"]}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err = Load(file)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	quota, err := cfg.QuotaBytes()
+	if err != nil {
+		t.Fatalf("QuotaBytes: %v", err)
+	}
+	if quota != 2<<30 {
+		t.Errorf("quota = %d, want %d", quota, 2<<30)
+	}
+	if len(cfg.Projects) != 1 || cfg.Projects[0] != "/src/app" {
+		t.Errorf("projects = %v", cfg.Projects)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"100":     100,
+		"10B":     10,
+		"512MiB":  512 << 20,
+		"1.5 GiB": 3 << 29,
+		"2G":      2 << 30,
+		"10GB":    10e9,
+	}
+	for in, want := range tests {
+		got, err := ParseSize(in)
+		if err != nil {
+			t.Errorf("ParseSize(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseSize(%q) = %d, want %d", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "GiB", "-1G", "ten"} {
+		if _, err := ParseSize(in); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("ParseSize(%q): err = %v, want ErrInvalidSize", in, err)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package gover
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid Go version")
+
+// Version is a Go release version such as go1.9, go1.21rc2 or go1.22.0.
+type Version struct {
+	Major int
+	Minor int
+	// Patch is -1 for versions without patch number: language versions
+	// (go1.21) and pre-releases (go1.21rc2)
+	Patch int
+	// Kind of pre-release: "alpha", "beta", "rc", or empty for releases
+	Kind string
+	// Pre is a number of pre-release
+	Pre int
+}
+
+// Parse parses Go version with optional "go" prefix.
+func Parse(s string) (Version, error) {
+	v, ok := parse(strings.TrimPrefix(s, "go"))
+	if !ok {
+		return Version{}, fmt.Errorf("%w %q", ErrInvalid, s)
+	}
+	return v, nil
+}
+
+// IsValid reports whether s is a valid Go version.
+func IsValid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+func parse(s string) (Version, bool) {
+	v := Version{Patch: -1}
+	var ok bool
+	if v.Major, s, ok = cutInt(s); !ok || v.Major == 0 {
+		return Version{}, false
+	}
+	if s == "" {
+		return v, true
+	}
+	if s[0] != '.' {
+		return Version{}, false
+	}
+	if v.Minor, s, ok = cutInt(s[1:]); !ok {
+		return Version{}, false
+	}
+	if s == "" {
+		return v, true
+	}
+	if s[0] == '.' {
+		if v.Patch, s, ok = cutInt(s[1:]); !ok || s != "" {
+			return Version{}, false
+		}
+		return v, true
+	}
+
+	i := strings.IndexAny(s, "0123456789")
+	if i <= 0 {
+		return Version{}, false
+	}
+	v.Kind = s[:i]
+	if v.Kind != "alpha" && v.Kind != "beta" && v.Kind != "rc" {
+		return Version{}, false
+	}
+	if v.Pre, s, ok = cutInt(s[i:]); !ok || s != "" {
+		return Version{}, false
+	}
+	return v, true
+}
+
+// cutInt parses decimal number without leading zeros at the start of s.
+func cutInt(s string) (int, string, bool) {
+	i := 0
+	for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || (s[0] == '0' && i > 1) {
+		return 0, "", false
+	}
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return 0, "", false
+	}
+	return n, s[i:], true
+}
+
+// String returns version in canonical form with "go" prefix.
+func (v Version) String() string {
+	switch {
+	case v.Kind != "":
+		return fmt.Sprintf("go%d.%d%s%d", v.Major, v.Minor, v.Kind, v.Pre)
+	case v.Patch >= 0:
+		return fmt.Sprintf("go%d.%d.%d", v.Major, v.Minor, v.Patch)
+	default:
+		return fmt.Sprintf("go%d.%d", v.Major, v.Minor)
+	}
+}
+
+// Semver returns version in semantic versioning form used by Go vulnerability
+// database, e.g. v1.21.0-rc.2 for go1.21rc2.
+func (v Version) Semver() string {
+	switch n := v.normalize(); {
+	case n.Kind != "":
+		return fmt.Sprintf("v%d.%d.0-%s.%d", n.Major, n.Minor, n.Kind, n.Pre)
+	case n.Patch < 0:
+		// language version precedes all pre-releases
+		return fmt.Sprintf("v%d.%d.0-0", n.Major, n.Minor)
+	default:
+		return fmt.Sprintf("v%d.%d.%d", n.Major, n.Minor, n.Patch)
+	}
+}
+
+// FromSemver converts semantic version with optional "v" prefix back to Go version,
+// e.g. 1.21.0-rc.2 to go1.21rc2.
+func FromSemver(s string) (Version, error) {
+	num, pre, _ := strings.Cut(strings.TrimPrefix(s, "v"), "-")
+	v, ok := parse(num)
+	if !ok || v.Patch < 0 {
+		return Version{}, fmt.Errorf("%w %q", ErrInvalid, s)
+	}
+	if pre == "" {
+		return v, nil
+	}
+	kind, n, ok := strings.Cut(pre, ".")
+	if !ok || v.Patch != 0 {
+		return Version{}, fmt.Errorf("%w %q", ErrInvalid, s)
+	}
+	w, ok := parse(fmt.Sprintf("%d.%d%s%s", v.Major, v.Minor, kind, n))
+	if !ok {
+		return Version{}, fmt.Errorf("%w %q", ErrInvalid, s)
+	}
+	return w, nil
+}
+
+// Lang returns language version (minor release line) of v, e.g. go1.22.
+func (v Version) Lang() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: -1}
+}
+
+// IsRelease reports whether v is a stable release, not a pre-release.
+func (v Version) IsRelease() bool {
+	return v.Kind == ""
+}
+
+// Compare returns -1, 0 or +1 depending on whether v < w, v == w or v > w.
+// Ordering is the same as of go/version.Compare:
+// go1.21 < go1.21rc1 < go1.21rc2 < go1.21.0 < go1.21.1.
+// Before Go 1.21 the first release of a minor line had no patch number,
+// so go1.20 is the same as go1.20.0 and newer than go1.20rc1.
+func (v Version) Compare(w Version) int {
+	v, w = v.normalize(), w.normalize()
+	if c := cmp.Compare(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(kindRank(v), kindRank(w)); c != 0 {
+		return c
+	}
+	if v.Kind != "" {
+		return cmp.Compare(v.Pre, w.Pre)
+	}
+	return cmp.Compare(v.Patch, w.Patch)
+}
+
+// normalize turns pre-Go 1.21 language versions into their .0 releases.
+func (v Version) normalize() Version {
+	if v.Kind == "" && v.Patch < 0 && v.Major == 1 && v.Minor < 21 {
+		v.Patch = 0
+	}
+	return v
+}
+
+// kindRank orders language versions before pre-releases and pre-releases before releases.
+func kindRank(v Version) int {
+	switch v.Kind {
+	case "alpha":
+		return 1
+	case "beta":
+		return 2
+	case "rc":
+		return 3
+	}
+	if v.Patch < 0 {
+		// language version
+		return 0
+	}
+	return 4
+}
+
+// Compare compares two version strings, invalid versions are considered
+// older than any valid version and equal to each other.
+func Compare(x, y string) int {
+	vx, errx := Parse(x)
+	vy, erry := Parse(y)
+	switch {
+	case errx != nil && erry != nil:
+		return 0
+	case errx != nil:
+		return -1
+	case erry != nil:
+		return 1
+	}
+	return vx.Compare(vy)
+}
+
+// SortDesc sorts items by version newest first. Items with invalid
+// versions are placed at the end in lexical order.
+func SortDesc[T any](items []T, version func(T) string) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		va, vb := version(a), version(b)
+		if c := Compare(vb, va); c != 0 {
+			return c
+		}
+		return strings.Compare(va, vb)
+	})
+}
+
+// Group is a set of items of the same minor release line.
+type Group[T any] struct {
+	// Lang is minor release line, zero for items with invalid versions
+	Lang  Version
+	Items []T
+}
+
+// GroupByLang sorts items newest first and groups them by minor release line.
+// Items with invalid versions form the last group.
+func GroupByLang[T any](items []T, version func(T) string) []Group[T] {
+	sorted := slices.Clone(items)
+	SortDesc(sorted, version)
+
+	var groups []Group[T]
+	for _, item := range sorted {
+		var lang Version
+		if v, err := Parse(version(item)); err == nil {
+			lang = v.Lang()
+		}
+		if n := len(groups); n > 0 && groups[n-1].Lang == lang {
+			groups[n-1].Items = append(groups[n-1].Items, item)
+			continue
+		}
+		groups = append(groups, Group[T]{Lang: lang, Items: []T{item}})
+	}
+	return groups
+}
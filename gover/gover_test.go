@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package gover
+
+import (
+	"errors"
+	goversion "go/version"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"go1.9", Version{Major: 1, Minor: 9, Patch: -1}},
+		{"1.22.0", Version{Major: 1, Minor: 22, Patch: 0}},
+		{"go1.21rc2", Version{Major: 1, Minor: 21, Patch: -1, Kind: "rc", Pre: 2}},
+		{"go1.20beta1", Version{Major: 1, Minor: 20, Patch: -1, Kind: "beta", Pre: 1}},
+		{"go1", Version{Major: 1, Patch: -1}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "go", "tip-abc", "go1.22.x", "go1.021", "go1.21rc", "go1.21gamma1", "go1.22.0.1", "devel go1.24-abc"} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q): err = %v, want ErrInvalid", in, err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, s := range []string{"go1.9", "go1.22.0", "go1.21rc2", "go1.20beta1"} {
+		v, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", s, err)
+		}
+		if v.String() != s {
+			t.Errorf("String() = %q, want %q", v.String(), s)
+		}
+	}
+	if v, _ := Parse("1.22.3"); v.Lang().String() != "go1.22" {
+		t.Errorf("Lang = %s, want go1.22", v.Lang())
+	}
+}
+
+func TestCompare_MatchesGoVersion(t *testing.T) {
+	versions := []string{
+		"go1", "go1.0", "go1.0.0", "go1.9", "go1.9.0", "go1.9.7", "go1.10", "go1.10.1", "go1.20", "go1.20.0", "go1.20beta1", "go1.20rc1",
+		"go1.21rc1", "go1.21rc2", "go1.21", "go1.21.0", "go1.21.1", "go1.21.10", "go1.22.0", "go2.0.0",
+	}
+	for _, x := range versions {
+		for _, y := range versions {
+			if got, want := Compare(x, y), goversion.Compare(x, y); got != want {
+				t.Errorf("Compare(%s, %s) = %d, go/version.Compare = %d", x, y, got, want)
+			}
+		}
+	}
+	if Compare("tip-abc", "go1.9") != -1 || Compare("go1.9", "custom") != 1 || Compare("a", "b") != 0 {
+		t.Error("invalid versions must be older than valid ones")
+	}
+}
+
+func TestSortDesc(t *testing.T) {
+	versions := []string{"1.9.7", "tip-abc", "1.22.0", "1.10", "1.21rc2", "1.21.0", "1.9", "custom"}
+	SortDesc(versions, func(s string) string { return s })
+	want := []string{"1.22.0", "1.21.0", "1.21rc2", "1.10", "1.9.7", "1.9", "custom", "tip-abc"}
+	if !slices.Equal(versions, want) {
+		t.Errorf("SortDesc = %v, want %v", versions, want)
+	}
+}
+
+func TestGroupByLang(t *testing.T) {
+	versions := []string{"1.21.0", "1.22.1", "tip-abc", "1.21.5", "1.22.0", "1.21rc2"}
+	groups := GroupByLang(versions, func(s string) string { return s })
+
+	var got [][]string
+	var langs []string
+	for _, g := range groups {
+		got = append(got, g.Items)
+		if g.Lang == (Version{}) {
+			langs = append(langs, "")
+		} else {
+			langs = append(langs, g.Lang.String())
+		}
+	}
+	if want := []string{"go1.22", "go1.21", ""}; !slices.Equal(langs, want) {
+		t.Errorf("groups = %v, want %v", langs, want)
+	}
+	want := [][]string{{"1.22.1", "1.22.0"}, {"1.21.5", "1.21.0", "1.21rc2"}, {"tip-abc"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("items = %v, want %v", got, want)
+	}
+}
+
+func TestSemver(t *testing.T) {
+	tests := map[string]string{
+		"go1.9":       "v1.9.0",
+		"go1.22.4":    "v1.22.4",
+		"go1.21rc2":   "v1.21.0-rc.2",
+		"go1.20beta1": "v1.20.0-beta.1",
+		"go1.21":      "v1.21.0-0",
+	}
+	for in, want := range tests {
+		v, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		if got := v.Semver(); got != want {
+			t.Errorf("Semver(%s) = %s, want %s", in, got, want)
+		}
+		if v.Patch < 0 && v.Kind == "" {
+			continue
+		}
+		back, err := FromSemver(want)
+		if err != nil {
+			t.Errorf("FromSemver(%q): %v", want, err)
+			continue
+		}
+		if back.Compare(v) != 0 {
+			t.Errorf("FromSemver(%q) = %s, want %s", want, back, in)
+		}
+	}
+	for _, in := range []string{"1.22", "1.22.1-rc.1", "1.22.0-gamma.1", "1.22.0-rc"} {
+		if _, err := FromSemver(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("FromSemver(%q): err = %v, want ErrInvalid", in, err)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/x-dvr/gm/progress"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var (
+	ErrUnknownSource = errors.New("unknown import source")
+	ErrUnknownMode   = errors.New("unknown import mode")
+)
+
+// Candidate is a toolchain found in the tree of another version manager.
+type Candidate struct {
+	// Version as recorded in VERSION file of toolchain, e.g. go1.22.0
+	Version string
+	// Path is GOROOT of toolchain
+	Path string
+	// Archive is set instead of Path for toolchains available
+	// only as golang.org/toolchain module zip.
+	Archive string
+}
+
+// Source finds toolchains installed by another version manager.
+type Source interface {
+	// Name returns identifier of the source used on command line.
+	Name() string
+	// Find returns toolchains installed by version manager.
+	Find() ([]Candidate, error)
+	// Default returns version globally selected in version manager
+	// or empty string if there is none.
+	Default() (string, error)
+}
+
+// Verifier is implemented by sources which can check integrity of found toolchains.
+type Verifier interface {
+	Verify(c Candidate) error
+}
+
+// Mode defines how toolchain files are brought into gm store.
+type Mode string
+
+const (
+	ModeCopy Mode = "copy"
+	ModeMove Mode = "move"
+	ModeLink Mode = "link"
+)
+
+var sources = map[string]Source{}
+
+func register(s Source) {
+	sources[s.Name()] = s
+}
+
+// Get returns import source by its name.
+func Get(name string) (Source, error) {
+	s, ok := sources[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q (supported: %s)", ErrUnknownSource, name, strings.Join(Names(), ", "))
+	}
+	return s, nil
+}
+
+// Names returns names of all supported import sources.
+func Names() []string {
+	return []string{"dl", "goenv", "gvm", "asdf", "g", "modcache"}
+}
+
+// ParseMode validates import mode given on command line.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeCopy, ModeMove, ModeLink:
+		return m, nil
+	default:
+		return "", fmt.Errorf("%w %q (supported: copy, move, link)", ErrUnknownMode, s)
+	}
+}
+
+// Import brings toolchain c into destPath using the given mode
+// and marks it as successfully installed.
+func Import(c Candidate, destPath string, mode Mode, tracker progress.IOTracker) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("create versions directory: %w", err)
+	}
+	tmpPath := destPath + ".import"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("cleanup previous import: %w", err)
+	}
+
+	switch {
+	case c.Archive != "":
+		tracker.Reset(fmt.Sprintf("Extracting %s ...", filepath.Base(c.Archive)))
+		if err := toolchain.UnpackModule(c.Archive, tmpPath, toolchain.ModuleVersion(c.Version)); err != nil {
+			os.RemoveAll(tmpPath)
+			return err
+		}
+	case mode == ModeMove:
+		if err := os.Rename(c.Path, tmpPath); err != nil {
+			// most probably different file systems
+			if err := copyTree(c.Path, tmpPath, false, tracker); err != nil {
+				os.RemoveAll(tmpPath)
+				return err
+			}
+			if err := os.RemoveAll(c.Path); err != nil {
+				return fmt.Errorf("remove %s: %w", c.Path, err)
+			}
+		}
+		// trees from module cache are read-only
+		if err := allowWrite(tmpPath); err != nil {
+			return fmt.Errorf("make %s writable: %w", tmpPath, err)
+		}
+	case mode == ModeLink:
+		if err := copyTree(c.Path, tmpPath, true, tracker); err != nil {
+			os.RemoveAll(tmpPath)
+			return err
+		}
+	default:
+		if err := copyTree(c.Path, tmpPath, false, tracker); err != nil {
+			os.RemoveAll(tmpPath)
+			return err
+		}
+	}
+
+	if err := toolchain.FixModuleLayout(tmpPath); err != nil {
+		return fmt.Errorf("prepare %s: %w", c.Version, err)
+	}
+	source := c.Path
+	if c.Archive != "" {
+		source = c.Archive
+	}
+	if err := toolchain.MarkInstalled(tmpPath, toolchain.InstallRecord{Source: source}); err != nil {
+		return fmt.Errorf("mark %s as installed: %w", c.Version, err)
+	}
+	if err := os.RemoveAll(destPath); err != nil {
+		return fmt.Errorf("cleanup previous installation: %w", err)
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("move toolchain into %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// copyTree recreates directory tree src at dst. If link is set, files are
+// hard-linked when possible and copied otherwise.
+func copyTree(src, dst string, link bool, tracker progress.IOTracker) error {
+	size, err := treeSize(src)
+	if err != nil {
+		return fmt.Errorf("calculate size of %s: %w", src, err)
+	}
+	tracker.SetSize(size)
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, fi.Mode().Perm()|0700)
+		case fi.Mode()&os.ModeSymlink != 0:
+			dest, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(dest, target)
+		case fi.Mode().IsRegular():
+			if link && os.Link(path, target) == nil {
+				return nil
+			}
+			return copyFile(path, target, fi.Mode().Perm(), tracker)
+		default:
+			return nil
+		}
+	})
+}
+
+// allowWrite makes all directories of the tree writable by owner.
+func allowWrite(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if fi.Mode().Perm()&0700 == 0700 {
+			return nil
+		}
+		return os.Chmod(path, fi.Mode().Perm()|0700)
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode, tracker progress.IOTracker) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(out, tracker.Writer()), in); err != nil {
+		out.Close()
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+	return out.Close()
+}
+
+func treeSize(root string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package importer
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/x-dvr/gm/toolchain"
+)
+
+type nopTracker struct{}
+
+func (nopTracker) Reset(string)      {}
+func (nopTracker) SetSize(int64)     {}
+func (nopTracker) Writer() io.Writer { return io.Discard }
+
+// writeToolchain creates minimal Go toolchain tree at goroot.
+func writeToolchain(t *testing.T, goroot, version string) {
+	t.Helper()
+	bin := toolchain.GoBinary(goroot)
+	if err := os.MkdirAll(filepath.Dir(bin), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(bin, []byte("binary"), 0755); err != nil {
+		t.Fatalf("write go binary: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(goroot, "VERSION"), []byte(version+"\ntime 2024-02-06T21:55:19Z\n"), 0644); err != nil {
+		t.Fatalf("write VERSION: %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"copy", "move", "link"} {
+		if m, err := ParseMode(s); err != nil || string(m) != s {
+			t.Errorf("ParseMode(%q) = %q, %v", s, m, err)
+		}
+	}
+	if _, err := ParseMode("symlink"); !errors.Is(err, ErrUnknownMode) {
+		t.Errorf("err = %v, want ErrUnknownMode", err)
+	}
+}
+
+func TestGet(t *testing.T) {
+	for _, name := range Names() {
+		s, err := Get(name)
+		if err != nil {
+			t.Errorf("Get(%q): %v", name, err)
+			continue
+		}
+		if s.Name() != name {
+			t.Errorf("Get(%q).Name() = %q", name, s.Name())
+		}
+	}
+	if _, err := Get("nvm"); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("err = %v, want ErrUnknownSource", err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	for _, mode := range []Mode{ModeCopy, ModeMove, ModeLink} {
+		t.Run(string(mode), func(t *testing.T) {
+			src := filepath.Join(t.TempDir(), "1.22.0")
+			writeToolchain(t, src, "go1.22.0")
+			dest := filepath.Join(t.TempDir(), "versions", "go1.22.0")
+
+			c := Candidate{Version: "go1.22.0", Path: src}
+			if err := Import(c, dest, mode, nopTracker{}); err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+
+			if !toolchain.IsInstalled(dest) {
+				t.Error("imported toolchain is not marked as installed")
+			}
+			if got, err := toolchain.ReadVersion(dest); err != nil || got != "go1.22.0" {
+				t.Errorf("ReadVersion = %q, %v, want go1.22.0", got, err)
+			}
+			if err := toolchain.CheckTree(dest); err != nil {
+				t.Errorf("CheckTree: %v", err)
+			}
+
+			_, err := os.Stat(src)
+			if mode == ModeMove && !os.IsNotExist(err) {
+				t.Errorf("source must be removed in move mode, stat err = %v", err)
+			}
+			if mode != ModeMove && err != nil {
+				t.Errorf("source must be kept in %s mode: %v", mode, err)
+			}
+			if _, err := os.Stat(dest + ".import"); !os.IsNotExist(err) {
+				t.Errorf("temporary directory must be removed, stat err = %v", err)
+			}
+		})
+	}
+}
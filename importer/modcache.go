@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+func init() {
+	register(modcacheSource{})
+}
+
+// modcacheSource finds toolchains downloaded by the go command
+// into module cache when switching toolchains with GOTOOLCHAIN.
+type modcacheSource struct{}
+
+func (modcacheSource) Name() string {
+	return "modcache"
+}
+
+func (modcacheSource) Find() ([]Candidate, error) {
+	root, err := modCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Candidate
+	seen := map[string]bool{}
+
+	// extracted modules
+	dirs, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(toolchain.ToolchainModule)+"@*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		_, modVersion, _ := strings.Cut(filepath.Base(dir), "@")
+		version, ok := toolchain.ParseModuleVersion(modVersion)
+		if !ok || toolchain.CheckTree(dir) != nil {
+			continue
+		}
+		seen[version] = true
+		found = append(found, Candidate{Version: version, Path: dir})
+	}
+
+	// downloaded, but never extracted modules
+	zips, err := filepath.Glob(filepath.Join(downloadDir(root), "*.zip"))
+	if err != nil {
+		return nil, err
+	}
+	for _, zip := range zips {
+		version, ok := toolchain.ParseModuleVersion(strings.TrimSuffix(filepath.Base(zip), ".zip"))
+		if !ok || seen[version] {
+			continue
+		}
+		found = append(found, Candidate{Version: version, Archive: zip})
+	}
+	return found, nil
+}
+
+func (modcacheSource) Default() (string, error) {
+	return "", nil
+}
+
+// Verify checks toolchain against hash recorded by the go command in .ziphash file
+// and against checksum database lookup result cached by the go command.
+func (modcacheSource) Verify(c Candidate) error {
+	root, err := modCacheDir()
+	if err != nil {
+		return err
+	}
+	mod := toolchain.ModuleVersion(c.Version)
+
+	var expected []string
+	zipHash, err := os.ReadFile(filepath.Join(downloadDir(root), mod.Version+".ziphash"))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read zip hash: %w", err)
+	}
+	if h := strings.TrimSpace(string(zipHash)); h != "" {
+		expected = append(expected, h)
+	}
+	sumHash, err := lookupSumDB(root, mod.Path, mod.Version)
+	if err != nil {
+		return fmt.Errorf("read checksum database cache: %w", err)
+	}
+	if sumHash != "" {
+		expected = append(expected, sumHash)
+	}
+	if len(expected) == 0 {
+		return fmt.Errorf("no checksum recorded for %s", mod)
+	}
+
+	var actual string
+	if c.Archive != "" {
+		actual, err = toolchain.HashModuleZip(c.Archive)
+	} else {
+		actual, err = toolchain.HashModuleDir(c.Path, mod)
+	}
+	if err != nil {
+		return fmt.Errorf("calculate hash of %s: %w", mod, err)
+	}
+	for _, h := range expected {
+		if h != actual {
+			return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, mod, h, actual)
+		}
+	}
+	return nil
+}
+
+// modCacheDir returns location of Go module cache.
+func modCacheDir() (string, error) {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir, nil
+	}
+	if gopath := filepath.SplitList(os.Getenv("GOPATH")); len(gopath) > 0 && gopath[0] != "" {
+		return filepath.Join(gopath[0], "pkg", "mod"), nil
+	}
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, "go", "pkg", "mod"), nil
+}
+
+// downloadDir returns directory of toolchain module in download cache.
+func downloadDir(root string) string {
+	return filepath.Join(root, "cache", "download", filepath.FromSlash(toolchain.ToolchainModule), "@v")
+}
+
+// lookupSumDB returns hash of module version from lookup results of
+// any checksum database cached by the go command, or empty string.
+func lookupSumDB(root, modPath, modVersion string) (string, error) {
+	pattern := filepath.Join(root, "cache", "download", "sumdb", "*", "lookup", filepath.FromSlash(modPath)+"@"+modVersion)
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		h, err := readFirstLine(file, func(line string) string {
+			fields := strings.Fields(line)
+			if len(fields) == 3 && fields[0] == modPath && fields[1] == modVersion {
+				return fields[2]
+			}
+			return ""
+		})
+		if err != nil {
+			return "", err
+		}
+		if h != "" {
+			return h, nil
+		}
+	}
+	return "", nil
+}
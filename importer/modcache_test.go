@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package importer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/x-dvr/gm/toolchain"
+	modzip "golang.org/x/mod/zip"
+)
+
+// writeModCache creates module cache with toolchain module of the given version,
+// downloaded and optionally extracted. It returns root of module cache.
+func writeModCache(t *testing.T, version string, extract bool) string {
+	t.Helper()
+	root := t.TempDir()
+	mod := toolchain.ModuleVersion(version)
+
+	src := t.TempDir()
+	writeToolchain(t, src, version)
+	writeFile(t, filepath.Join(src, "src", "_go.mod"), "module std\n")
+
+	dl := downloadDir(root)
+	if err := os.MkdirAll(dl, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	zipPath := filepath.Join(dl, mod.Version+".zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+	if err := modzip.CreateFromDir(f, mod, src); err != nil {
+		t.Fatalf("CreateFromDir: %v", err)
+	}
+	f.Close()
+
+	h, err := toolchain.HashModuleZip(zipPath)
+	if err != nil {
+		t.Fatalf("HashModuleZip: %v", err)
+	}
+	writeFile(t, filepath.Join(dl, mod.Version+".ziphash"), h+"\n")
+	writeFile(t, filepath.Join(root, "cache", "download", "sumdb", "sum.golang.org", "lookup", "golang.org", "toolchain@"+mod.Version),
+		"12345\n"+mod.Path+" "+mod.Version+" "+h+"\n"+mod.Path+" "+mod.Version+"/go.mod h1:abc=\n\ngo.sum database tree\n")
+
+	if extract {
+		dir := filepath.Join(root, "golang.org", "toolchain@"+mod.Version)
+		if err := toolchain.UnpackModule(zipPath, dir, mod); err != nil {
+			t.Fatalf("UnpackModule: %v", err)
+		}
+	}
+	return root
+}
+
+func TestModcacheSource(t *testing.T) {
+	for _, extract := range []bool{true, false} {
+		name := "zip"
+		if extract {
+			name = "extracted"
+		}
+		t.Run(name, func(t *testing.T) {
+			root := writeModCache(t, "go1.22.4", extract)
+			t.Setenv("GOMODCACHE", root)
+
+			s, err := Get("modcache")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			found, err := s.Find()
+			if err != nil {
+				t.Fatalf("Find: %v", err)
+			}
+			if len(found) != 1 || found[0].Version != "go1.22.4" {
+				t.Fatalf("got %+v, want single go1.22.4", found)
+			}
+			c := found[0]
+			if extract == (c.Archive != "") {
+				t.Errorf("got %+v, want archive only for not extracted module", c)
+			}
+
+			if err := s.(Verifier).Verify(c); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			dest := filepath.Join(t.TempDir(), "go1.22.4")
+			if err := Import(c, dest, ModeCopy, nopTracker{}); err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if !toolchain.IsInstalled(dest) {
+				t.Error("imported toolchain is not marked as installed")
+			}
+			if _, err := os.Stat(filepath.Join(dest, "src", "go.mod")); err != nil {
+				t.Errorf("src/go.mod must be restored: %v", err)
+			}
+		})
+	}
+}
+
+func TestModcacheSource_VerifyMismatch(t *testing.T) {
+	root := writeModCache(t, "go1.22.4", true)
+	t.Setenv("GOMODCACHE", root)
+
+	s, err := Get("modcache")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	found, err := s.Find()
+	if err != nil || len(found) != 1 {
+		t.Fatalf("Find = %v, %v", found, err)
+	}
+
+	// tamper with extracted toolchain
+	if err := os.WriteFile(filepath.Join(found[0].Path, "VERSION"), []byte("go1.22.5"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := s.(Verifier).Verify(found[0]); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("err = %v, want ErrChecksumMismatch", err)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/x-dvr/gm/toolchain"
+)
+
+func init() {
+	register(treeSource{
+		name: "dl",
+		home: homePath("", "sdk"),
+	})
+	register(treeSource{
+		name:           "goenv",
+		home:           homePath("GOENV_ROOT", ".goenv"),
+		versions:       "versions",
+		defaultVersion: goenvDefault,
+	})
+	register(treeSource{
+		name:           "gvm",
+		home:           homePath("GVM_ROOT", ".gvm"),
+		versions:       "gos",
+		defaultVersion: gvmDefault,
+	})
+	register(treeSource{
+		name:           "asdf",
+		home:           homePath("ASDF_DATA_DIR", ".asdf"),
+		versions:       filepath.Join("installs", "golang"),
+		goroot:         "go",
+		defaultVersion: asdfDefault,
+	})
+	register(treeSource{
+		name:           "g",
+		home:           homePath("G_HOME", ".g"),
+		versions:       "versions",
+		defaultVersion: gDefault,
+	})
+}
+
+// treeSource finds toolchains in a directory holding
+// one subdirectory per installed version.
+type treeSource struct {
+	name string
+	// home returns root directory of version manager
+	home func() (string, error)
+	// versions is a path of directory with installed versions relative to home
+	versions string
+	// goroot is a path of GOROOT relative to version directory
+	goroot string
+	// defaultVersion returns globally selected version
+	defaultVersion func(home string) (string, error)
+}
+
+func (s treeSource) Name() string {
+	return s.name
+}
+
+func (s treeSource) Find() ([]Candidate, error) {
+	home, err := s.home()
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Join(home, s.versions)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", root, err)
+	}
+
+	var found []Candidate
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		goroot := filepath.Join(root, entry.Name(), s.goroot)
+		if toolchain.CheckTree(goroot) != nil {
+			continue
+		}
+		version, err := toolchain.ReadVersion(goroot)
+		if err != nil {
+			return nil, fmt.Errorf("read version of %s: %w", goroot, err)
+		}
+		if version == "" {
+			// development builds can't be verified
+			continue
+		}
+		found = append(found, Candidate{Version: version, Path: goroot})
+	}
+	return found, nil
+}
+
+func (s treeSource) Default() (string, error) {
+	if s.defaultVersion == nil {
+		return "", nil
+	}
+	home, err := s.home()
+	if err != nil {
+		return "", err
+	}
+	version, err := s.defaultVersion(home)
+	if err != nil {
+		return "", fmt.Errorf("get default version of %s: %w", s.name, err)
+	}
+	if version != "" && !strings.HasPrefix(version, "go") {
+		version = "go" + version
+	}
+	return version, nil
+}
+
+// homePath returns function resolving root directory of version manager:
+// value of env variable if it is set, or dir in the home directory of user.
+func homePath(env, dir string) func() (string, error) {
+	return func() (string, error) {
+		if env != "" {
+			if root := os.Getenv(env); root != "" {
+				return root, nil
+			}
+		}
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("get home dir of user: %w", err)
+		}
+		return filepath.Join(homedir, dir), nil
+	}
+}
+
+// goenvDefault reads global version file of goenv: $GOENV_ROOT/version
+func goenvDefault(home string) (string, error) {
+	return readFirstLine(filepath.Join(home, "version"), func(line string) string {
+		if line == "system" {
+			return ""
+		}
+		return line
+	})
+}
+
+var reGvmGoroot = regexp.MustCompile(`gos/(go[^"'/\s]+)`)
+
+// gvmDefault reads GOROOT from default environment of gvm: $GVM_ROOT/environments/default
+func gvmDefault(home string) (string, error) {
+	return readFirstLine(filepath.Join(home, "environments", "default"), func(line string) string {
+		if !strings.Contains(line, "GOROOT") {
+			return ""
+		}
+		if m := reGvmGoroot.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+		return ""
+	})
+}
+
+// asdfDefault reads golang entry of global tool versions file: ~/.tool-versions
+func asdfDefault(string) (string, error) {
+	path := os.Getenv("ASDF_DEFAULT_TOOL_VERSIONS_FILENAME")
+	if path == "" {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("get home dir of user: %w", err)
+		}
+		path = filepath.Join(homedir, ".tool-versions")
+	}
+	return readFirstLine(path, func(line string) string {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "golang" || fields[1] == "system" {
+			return ""
+		}
+		return fields[1]
+	})
+}
+
+// gDefault resolves symlink to the current version of g: $G_HOME/go
+func gDefault(home string) (string, error) {
+	target, err := os.Readlink(filepath.Join(home, "go"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return filepath.Base(target), nil
+}
+
+// readFirstLine returns the first non-empty result of match applied
+// to lines of the file, missing file is not an error.
+func readFirstLine(path string, match func(line string) string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if v := match(line); v != "" {
+			return v, nil
+		}
+	}
+	return "", scanner.Err()
+}
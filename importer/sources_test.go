@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// setHome overrides the user's home directory for the duration of the test.
+func setHome(t *testing.T, dir string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Setenv("USERPROFILE", dir)
+	} else {
+		t.Setenv("HOME", dir)
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestTreeSource_Find(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	t.Setenv("ASDF_DATA_DIR", "")
+
+	installs := filepath.Join(home, ".asdf", "installs", "golang")
+	writeToolchain(t, filepath.Join(installs, "1.22.0", "go"), "go1.22.0")
+	writeToolchain(t, filepath.Join(installs, "1.21.5", "go"), "go1.21.5")
+	// broken installation without binaries
+	writeFile(t, filepath.Join(installs, "1.20.0", "go", "VERSION"), "go1.20")
+
+	s, err := Get("asdf")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	found, err := s.Find()
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+
+	want := map[string]string{
+		"go1.21.5": filepath.Join(installs, "1.21.5", "go"),
+		"go1.22.0": filepath.Join(installs, "1.22.0", "go"),
+	}
+	if len(found) != len(want) {
+		t.Fatalf("got %v, want %d toolchains", found, len(want))
+	}
+	for _, c := range found {
+		if want[c.Version] != c.Path {
+			t.Errorf("got %+v, want path %q", c, want[c.Version])
+		}
+	}
+}
+
+func TestTreeSource_FindMissingRoot(t *testing.T) {
+	setHome(t, t.TempDir())
+	t.Setenv("GOENV_ROOT", "")
+
+	s, err := Get("goenv")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	found, err := s.Find()
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("got %v, want nothing", found)
+	}
+}
+
+func TestTreeSource_Default(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	goenvRoot := filepath.Join(t.TempDir(), "goenv")
+	t.Setenv("GOENV_ROOT", goenvRoot)
+	writeFile(t, filepath.Join(goenvRoot, "version"), "1.22.0\n")
+
+	t.Setenv("GVM_ROOT", "")
+	writeFile(t, filepath.Join(home, ".gvm", "environments", "default"),
+		"export GVM_ROOT; GVM_ROOT=\"/home/u/.gvm\"\nexport GOROOT; GOROOT=\"$GVM_ROOT/gos/go1.21.3\"\n")
+
+	t.Setenv("ASDF_DEFAULT_TOOL_VERSIONS_FILENAME", "")
+	writeFile(t, filepath.Join(home, ".tool-versions"), "nodejs 20.0.0\ngolang 1.20.14\n")
+
+	tests := map[string]string{
+		"goenv": "go1.22.0",
+		"gvm":   "go1.21.3",
+		"asdf":  "go1.20.14",
+		"dl":    "",
+		"g":     "",
+	}
+	for name, want := range tests {
+		s, err := Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		got, err := s.Default()
+		if err != nil {
+			t.Errorf("%s: Default: %v", name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: Default = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestTreeSource_DefaultG(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+	gHome := t.TempDir()
+	t.Setenv("G_HOME", gHome)
+	if err := os.Symlink(filepath.Join(gHome, "versions", "1.22.4"), filepath.Join(gHome, "go")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	s, err := Get("g")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := s.Default()
+	if err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	if got != "go1.22.4" {
+		t.Errorf("Default = %q, want go1.22.4", got)
+	}
+}
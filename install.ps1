@@ -114,14 +114,14 @@ if ($CurrentPath -notlike "*$INSTALL_DIR*") {
     Write-Host "ℹ️  PATH already contains $INSTALL_DIR" -ForegroundColor Yellow
 }
 
-# Run gm env to set up Go environment variables
+# Run gm init to set up Go environment variables
 Write-Host ""
 Write-Host "⚙️  Configuring Go environment variables..." -ForegroundColor Cyan
 try {
-    & "$DEST_BINARY" env
+    & "$DEST_BINARY" init
 } catch {
     Write-Host "⚠️  Could not configure environment variables: $_" -ForegroundColor Yellow
-    Write-Host "   You can run 'gm env' manually later" -ForegroundColor Yellow
+    Write-Host "   You can run 'gm init' manually later" -ForegroundColor Yellow
 }
 
 Write-Host ""
@@ -56,73 +56,15 @@ echo "📥 Installing to $INSTALL_DIR/$BINARY_NAME..."
 mv "$BINARY_NAME" "$INSTALL_DIR/$BINARY_NAME"
 chmod +x "$INSTALL_DIR/$BINARY_NAME"
 
-# Update PATH if needed
-if [[ ":$PATH:" != *":$INSTALL_DIR:"* ]]; then
-    echo ""
-    echo "⚙️  Adding $INSTALL_DIR to PATH..."
-
-    # Detect shell and update appropriate profile
-    SHELL_PROFILE=""
-    DETECTED_SHELL=$(basename "$SHELL")
-
-    case "$DETECTED_SHELL" in
-        zsh)
-            SHELL_PROFILE="$HOME/.zshenv"
-            # Create .zshenv if it doesn't exist
-            if [ ! -f "$SHELL_PROFILE" ]; then
-                touch "$SHELL_PROFILE"
-            fi
-            ;;
-        bash)
-            if [ -f "$HOME/.bashrc" ]; then
-                SHELL_PROFILE="$HOME/.bashrc"
-            elif [ -f "$HOME/.bash_profile" ]; then
-                SHELL_PROFILE="$HOME/.bash_profile"
-            fi
-            ;;
-        fish)
-            SHELL_PROFILE="$HOME/.config/fish/config.fish"
-            # Create config.fish if it doesn't exist
-            mkdir -p "$HOME/.config/fish"
-            if [ ! -f "$SHELL_PROFILE" ]; then
-                touch "$SHELL_PROFILE"
-            fi
-            ;;
-        *)
-            # For other shells, try common profile files
-            if [ -f "$HOME/.profile" ]; then
-                SHELL_PROFILE="$HOME/.profile"
-            fi
-            ;;
-    esac
-
-    if [ -n "$SHELL_PROFILE" ]; then
-        # Check if already added
-        if grep -q "# GM (Go version manager)" "$SHELL_PROFILE" 2>/dev/null; then
-            echo "ℹ️  PATH already configured in $SHELL_PROFILE"
-        else
-            echo "" >> "$SHELL_PROFILE"
-            echo "# GM (Go version manager)" >> "$SHELL_PROFILE"
-
-            # Use appropriate syntax for the shell
-            if [ "$DETECTED_SHELL" = "fish" ]; then
-                echo "set -gx PATH \$HOME/.gm/bin \$PATH" >> "$SHELL_PROFILE"
-                echo "gm env | source" >> "$SHELL_PROFILE"
-            else
-                echo "export PATH=\"\$HOME/.gm/bin:\$PATH\"" >> "$SHELL_PROFILE"
-                echo "eval \$(gm env)" >> "$SHELL_PROFILE"
-            fi
-
-            echo "✅ Updated $SHELL_PROFILE"
-        fi
-    else
-        echo "⚠️  Could not detect shell profile (shell: $DETECTED_SHELL)"
-        echo "   Please add manually: export PATH=\"\$HOME/.gm/bin:\$PATH\""
-    fi
+# Set up shell profile
+echo ""
+echo "⚙️  Setting up shell profile..."
+if ! "$INSTALL_DIR/$BINARY_NAME" init; then
+    echo "⚠️  Could not set up shell profile (shell: $(basename "$SHELL"))"
+    echo "   Please run '$INSTALL_DIR/$BINARY_NAME init --shell <shell>' manually"
 fi
 
 echo ""
 echo "✅ Successfully installed $BINARY_NAME $VERSION"
 echo ""
-echo "Run: source $SHELL_PROFILE"
-echo "And then run '$BINARY_NAME --version' to verify installation"
+echo "Start a new shell and then run '$BINARY_NAME --version' to verify installation"
@@ -0,0 +1,187 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Job is a run of command in its own environment.
+type Job struct {
+	// Name identifies job in output and reports, e.g. Go version
+	Name string
+	Env  []string
+}
+
+// Result is an outcome of a job.
+type Result struct {
+	Name string
+	// ExitCode of command, -1 if it was not started or killed
+	ExitCode int
+	// Err is nil if command succeeded
+	Err      error
+	Duration time.Duration
+	// Output is combined standard output and error of command
+	Output []byte
+}
+
+// Passed reports whether command succeeded.
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Runner runs command once per job.
+type Runner struct {
+	// Args is command with arguments, command is looked up in PATH of job environment
+	Args []string
+	// Dir is working directory of command, empty for the current one
+	Dir string
+	// Parallel limits number of jobs run at once, jobs are not limited if it is not positive
+	Parallel int
+	// Out receives output of jobs as it is produced, each line prefixed with job name.
+	// Output is only captured into results when it is nil.
+	Out io.Writer
+	// Done is called when a job finishes, calls are not concurrent
+	Done func(Result)
+}
+
+// Run runs jobs and returns their results in order of jobs.
+func (r *Runner) Run(ctx context.Context, jobs []Job) []Result {
+	parallel := r.Parallel
+	if parallel <= 0 || parallel > len(jobs) {
+		parallel = len(jobs)
+	}
+	sem := make(chan struct{}, parallel)
+	// serializes writes to Out and calls of Done
+	var mu sync.Mutex
+	results := make([]Result, len(jobs))
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			res := r.run(ctx, job, &mu)
+			results[i] = res
+			if r.Done != nil {
+				mu.Lock()
+				r.Done(res)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (r *Runner) run(ctx context.Context, job Job, mu *sync.Mutex) Result {
+	res := Result{Name: job.Name, ExitCode: -1}
+	var output bytes.Buffer
+	var w io.Writer = &output
+	var pw *prefixWriter
+	if r.Out != nil {
+		pw = &prefixWriter{mu: mu, out: r.Out, prefix: "[" + job.Name + "] "}
+		w = io.MultiWriter(&output, pw)
+	}
+
+	start := time.Now()
+	path, err := lookPath(r.Args[0], job.Env)
+	if err == nil {
+		c := exec.CommandContext(ctx, path, r.Args[1:]...)
+		c.Env = job.Env
+		c.Dir = r.Dir
+		c.Stdout, c.Stderr = w, w
+		err = c.Run()
+	}
+	res.Duration = time.Since(start)
+	if pw != nil {
+		pw.Flush()
+	}
+	res.Output = output.Bytes()
+
+	res.Err = err
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		res.ExitCode = 0
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	}
+	return res
+}
+
+// lookPath searches for command in PATH of env, jobs run with
+// different PATH than the current process.
+func lookPath(file string, env []string) (string, error) {
+	if strings.ContainsAny(file, `/\`) {
+		return file, nil
+	}
+	path := ""
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		if name == "PATH" || (runtime.GOOS == "windows" && strings.EqualFold(name, "PATH")) {
+			path = value
+		}
+	}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		// tries executable extensions on Windows
+		if found, err := exec.LookPath(filepath.Join(dir, file)); err == nil {
+			return found, nil
+		}
+	}
+	return "", &exec.Error{Name: file, Err: exec.ErrNotFound}
+}
+
+// prefixWriter writes complete lines to out with prefix.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	w.emit(w.buf[:i+1])
+	w.buf = w.buf[i+1:]
+	return len(p), nil
+}
+
+// Flush writes incomplete last line.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.emit(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) emit(lines []byte) {
+	var b bytes.Buffer
+	for line := range bytes.Lines(lines) {
+		b.WriteString(w.prefix)
+		b.Write(line)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// output is best effort, failing terminal must not fail the job
+	_, _ = w.out.Write(b.Bytes())
+}
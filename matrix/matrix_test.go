@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestMain runs test binary as a command of jobs when MATRIX_TEST_EXIT is set.
+func TestMain(m *testing.M) {
+	if code, ok := os.LookupEnv("MATRIX_TEST_EXIT"); ok {
+		fmt.Printf("version %s\nexit", os.Getenv("MATRIX_TEST_VERSION"))
+		n, _ := strconv.Atoi(code)
+		os.Exit(n)
+	}
+	os.Exit(m.Run())
+}
+
+func TestRunner(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("executable: %v", err)
+	}
+	jobs := []Job{
+		{Name: "1.21.13", Env: []string{"MATRIX_TEST_EXIT=0", "MATRIX_TEST_VERSION=1.21.13"}},
+		{Name: "1.22.4", Env: []string{"MATRIX_TEST_EXIT=3", "MATRIX_TEST_VERSION=1.22.4"}},
+		{Name: "1.23.0", Env: []string{"MATRIX_TEST_EXIT=0", "MATRIX_TEST_VERSION=1.23.0"}},
+	}
+	var out bytes.Buffer
+	var done []string
+	r := &Runner{
+		Args:     []string{exe},
+		Parallel: 2,
+		Out:      &out,
+		Done:     func(res Result) { done = append(done, res.Name) },
+	}
+	results := r.Run(context.Background(), jobs)
+
+	if len(results) != len(jobs) || len(done) != len(jobs) {
+		t.Fatalf("results = %d, done = %d, want %d", len(results), len(done), len(jobs))
+	}
+	for i, res := range results {
+		name := jobs[i].Name
+		wantCode := 0
+		if name == "1.22.4" {
+			wantCode = 3
+		}
+		if res.Name != name || res.ExitCode != wantCode || res.Passed() != (wantCode == 0) {
+			t.Errorf("results[%d] = %s exit %d (err %v), want %s exit %d", i, res.Name, res.ExitCode, res.Err, name, wantCode)
+		}
+		if want := "version " + name + "\nexit"; string(res.Output) != want {
+			t.Errorf("results[%d].Output = %q, want %q", i, res.Output, want)
+		}
+		for _, line := range []string{"[" + name + "] version " + name + "\n", "[" + name + "] exit\n"} {
+			if !strings.Contains(out.String(), line) {
+				t.Errorf("output %q does not contain %q", out.String(), line)
+			}
+		}
+	}
+}
+
+func TestRunnerNotFound(t *testing.T) {
+	r := &Runner{Args: []string{"gm-matrix-missing"}}
+	results := r.Run(context.Background(), []Job{{Name: "1.22.4", Env: []string{"PATH=" + t.TempDir()}}})
+	if res := results[0]; res.Passed() || res.ExitCode != -1 || !errors.Is(res.Err, exec.ErrNotFound) {
+		t.Errorf("result = exit %d, err %v, want exec.ErrNotFound", res.ExitCode, res.Err)
+	}
+}
+
+func TestLookPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executables need extension on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	for _, dir := range []string{first, second} {
+		if err := os.WriteFile(filepath.Join(dir, "go"), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	env := []string{"PATH=/nonexistent", "PATH=" + second + string(os.PathListSeparator) + first}
+	if got, err := lookPath("go", env); err != nil || got != filepath.Join(second, "go") {
+		t.Errorf("lookPath = %q, %v, want %q", got, err, filepath.Join(second, "go"))
+	}
+	if got, err := lookPath("./go", env); err != nil || got != "./go" {
+		t.Errorf("lookPath(./go) = %q, %v, want ./go", got, err)
+	}
+}
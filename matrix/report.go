@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package matrix
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Report describes results of command run with each toolchain.
+type Report struct {
+	Command []string     `json:"command"`
+	Results []ReportItem `json:"results"`
+}
+
+// ReportItem is a result of command run with one toolchain.
+type ReportItem struct {
+	Name     string  `json:"name"`
+	Passed   bool    `json:"passed"`
+	ExitCode int     `json:"exit_code"`
+	Duration float64 `json:"duration_seconds"`
+	Error    string  `json:"error,omitempty"`
+	Output   string  `json:"output"`
+}
+
+// NewReport returns report of results of command args.
+func NewReport(args []string, results []Result) *Report {
+	rep := &Report{Command: args, Results: make([]ReportItem, 0, len(results))}
+	for _, res := range results {
+		item := ReportItem{
+			Name:     res.Name,
+			Passed:   res.Passed(),
+			ExitCode: res.ExitCode,
+			Duration: res.Duration.Seconds(),
+			Output:   string(res.Output),
+		}
+		if res.Err != nil {
+			item.Error = res.Err.Error()
+		}
+		rep.Results = append(rep.Results, item)
+	}
+	return rep
+}
+
+// WriteFile writes report to file, in JUnit XML format if file has .xml extension
+// or in JSON format otherwise.
+func (rep *Report) WriteFile(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("create report: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(file), ".xml") {
+		err = rep.WriteJUnit(f)
+	} else {
+		err = rep.WriteJSON(f)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close report: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes report in JSON format.
+func (rep *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(rep); err != nil {
+		return fmt.Errorf("write JSON report: %w", err)
+	}
+	return nil
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes report in JUnit XML format, each toolchain is a test case.
+func (rep *Report) WriteJUnit(w io.Writer) error {
+	command := strings.Join(rep.Command, " ")
+	suite := junitSuite{Name: command, Tests: len(rep.Results)}
+	total := 0.0
+	for _, item := range rep.Results {
+		tc := junitCase{
+			Name:      item.Name,
+			ClassName: command,
+			Time:      fmt.Sprintf("%.3f", item.Duration),
+			SystemOut: item.Output,
+		}
+		if !item.Passed {
+			suite.Failures++
+			tc.Failure = &junitFailure{Message: item.Error}
+		}
+		total += item.Duration
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Time = fmt.Sprintf("%.3f", total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write JUnit report: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return fmt.Errorf("write JUnit report: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write JUnit report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package matrix
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReport() *Report {
+	return NewReport([]string{"go", "test", "./..."}, []Result{
+		{Name: "1.21.13", Duration: 1500 * time.Millisecond, Output: []byte("ok\n")},
+		{Name: "1.22.4", ExitCode: 1, Err: errors.New("exit status 1"), Duration: 2 * time.Second, Output: []byte("FAIL\n")},
+	})
+}
+
+func TestWriteFileJSON(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "matrix.json")
+	if err := testReport().WriteFile(file); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var got Report
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := ReportItem{Name: "1.22.4", ExitCode: 1, Duration: 2, Error: "exit status 1", Output: "FAIL\n"}
+	if len(got.Results) != 2 || !got.Results[0].Passed || got.Results[1] != want {
+		t.Errorf("results = %+v, want second %+v", got.Results, want)
+	}
+}
+
+func TestWriteFileJUnit(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "matrix.xml")
+	if err := testReport().WriteFile(file); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.HasPrefix(string(data), xml.Header) {
+		t.Errorf("report does not start with XML header: %q", data)
+	}
+	var got junitSuites
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(got.Suites) != 1 {
+		t.Fatalf("suites = %d, want 1", len(got.Suites))
+	}
+	suite := got.Suites[0]
+	if suite.Name != "go test ./..." || suite.Tests != 2 || suite.Failures != 1 || suite.Time != "3.500" {
+		t.Errorf("suite = %s tests %d failures %d time %s", suite.Name, suite.Tests, suite.Failures, suite.Time)
+	}
+	if len(suite.Cases) != 2 || suite.Cases[0].Failure != nil || suite.Cases[1].Failure == nil ||
+		suite.Cases[1].Failure.Message != "exit status 1" || suite.Cases[1].SystemOut != "FAIL\n" {
+		t.Errorf("cases = %+v", suite.Cases)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// File is a name of policy file looked up in repository and its parents.
+const File = ".gm-policy.json"
+
+var ErrInvalidRange = errors.New("invalid version range")
+
+// Policy restricts versions of Go toolchain which may be installed and used.
+type Policy struct {
+	// Minimum is the oldest allowed version
+	Minimum string `json:"minimum,omitempty"`
+	// Allow lists ranges of allowed versions, any version is allowed if empty
+	Allow []string `json:"allow,omitempty"`
+	// Deny lists ranges of forbidden versions
+	Deny []string `json:"deny,omitempty"`
+	// ForbidUnsupported forbids versions of minor lines no longer supported by Go team
+	ForbidUnsupported bool `json:"forbid_unsupported,omitempty"`
+	// ForbidVulnerable forbids versions with known vulnerabilities
+	ForbidVulnerable bool `json:"forbid_vulnerable,omitempty"`
+
+	// Path is a file policy was loaded from
+	Path string `json:"-"`
+}
+
+// Facts are data about versions some of the policy rules need.
+// Version is forbidden by rules which data is missing for.
+type Facts struct {
+	Catalog *toolchain.Catalog
+	// Vulnerabilities returns IDs of known vulnerabilities of version
+	Vulnerabilities func(version string) ([]string, error)
+}
+
+// Violation describes why version is forbidden by policy.
+type Violation struct {
+	Version string
+	Policy  string
+	Reason  string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("Go %s is forbidden by policy %s: %s", strings.TrimPrefix(v.Version, "go"), v.Policy, v.Reason)
+}
+
+// Load reads policy from file.
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read policy: %w", err)
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("decode policy %s: %w", file, err)
+	}
+	p.Path = file
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("policy %s: %w", file, err)
+	}
+	return &p, nil
+}
+
+// Find returns path of policy file of repository dir belongs to,
+// looking in dir and its parents. It returns empty path if there is none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		file := filepath.Join(dir, File)
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
+		} else if !os.IsNotExist(err) {
+			return "", fmt.Errorf("check policy file: %w", err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func (p *Policy) validate() error {
+	if p.Minimum != "" && !gover.IsValid("go"+strings.TrimPrefix(p.Minimum, "go")) {
+		return fmt.Errorf("minimum: invalid Go version %q", p.Minimum)
+	}
+	for _, r := range append(p.Allow, p.Deny...) {
+		if _, err := ParseRange(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check returns *Violation if version is forbidden by policy.
+func (p *Policy) Check(version string, facts Facts) error {
+	violation := func(reason string) error {
+		return &Violation{Version: version, Policy: p.Path, Reason: reason}
+	}
+	v, err := gover.Parse(version)
+	if err != nil {
+		if p.Minimum != "" || len(p.Allow) > 0 {
+			return violation("version is not a Go release and can not be checked")
+		}
+		return nil
+	}
+
+	if p.Minimum != "" && gover.Compare(version, "go"+strings.TrimPrefix(p.Minimum, "go")) < 0 {
+		return violation(fmt.Sprintf("older than minimum version %s", strings.TrimPrefix(p.Minimum, "go")))
+	}
+	for _, r := range p.Deny {
+		if rng, _ := ParseRange(r); rng.Contains(v) {
+			return violation(fmt.Sprintf("denied by range %q", r))
+		}
+	}
+	if len(p.Allow) > 0 {
+		allowed := false
+		for _, r := range p.Allow {
+			if rng, _ := ParseRange(r); rng.Contains(v) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return violation(fmt.Sprintf("not in allowed ranges %q", strings.Join(p.Allow, `", "`)))
+		}
+	}
+	// rules fail closed, version is forbidden when they can not be evaluated
+	if p.ForbidUnsupported {
+		if facts.Catalog == nil {
+			return violation("support status can not be checked, release catalog is not available (run 'gm outdated' to fetch it)")
+		}
+		if st := facts.Catalog.Status(version); st.Unsupported {
+			return violation(fmt.Sprintf("%s is no longer supported", v.Lang()))
+		}
+	}
+	if p.ForbidVulnerable {
+		if facts.Vulnerabilities == nil {
+			return violation("vulnerabilities can not be checked, vulnerability database is not available (run 'gm audit' to fetch it)")
+		}
+		ids, err := facts.Vulnerabilities(version)
+		if err != nil {
+			return violation(fmt.Sprintf("vulnerabilities can not be checked: %s (run 'gm audit' to fetch database)", err))
+		}
+		if len(ids) > 0 {
+			return violation("known vulnerabilities " + strings.Join(ids, ", "))
+		}
+	}
+	return nil
+}
+
+// Range is a set of Go versions.
+type Range struct {
+	conds []cond
+}
+
+type cond struct {
+	op      string
+	version gover.Version
+	// line matches any version of minor line
+	line bool
+}
+
+// ParseRange parses range of versions. Range is either a minor line ("1.21" or "1.21.x"),
+// exact version ("1.21.3") or space separated comparisons which all must hold
+// (">=1.21.0 <1.21.5"). Supported operators are =, <, <=, > and >=.
+func ParseRange(s string) (Range, error) {
+	var r Range
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return r, fmt.Errorf("%w %q", ErrInvalidRange, s)
+	}
+	for _, f := range fields {
+		op := ""
+		for _, o := range []string{">=", "<=", ">", "<", "="} {
+			if rest, ok := strings.CutPrefix(f, o); ok {
+				op, f = o, rest
+				break
+			}
+		}
+		line := false
+		if rest, ok := strings.CutSuffix(f, ".x"); ok {
+			f, line = rest, true
+		}
+		v, err := gover.Parse(f)
+		if err != nil {
+			return r, fmt.Errorf("%w %q", ErrInvalidRange, s)
+		}
+		if v.Patch < 0 && v.Kind == "" && op == "" {
+			line = true
+		}
+		if line && (op != "" || v.Patch >= 0 || v.Kind != "") {
+			return r, fmt.Errorf("%w %q: minor line can not be compared", ErrInvalidRange, s)
+		}
+		if op == "" {
+			op = "="
+		}
+		r.conds = append(r.conds, cond{op: op, version: v, line: line})
+	}
+	return r, nil
+}
+
+// Contains reports whether version belongs to range.
+func (r Range) Contains(v gover.Version) bool {
+	if len(r.conds) == 0 {
+		return false
+	}
+	for _, c := range r.conds {
+		if c.line {
+			if v.Lang() != c.version.Lang() {
+				return false
+			}
+			continue
+		}
+		cmp := v.Compare(c.version)
+		ok := false
+		switch c.op {
+		case "=":
+			ok = cmp == 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// LogOverride appends record of policy ignored with --ignore-policy to log file.
+func LogOverride(file, command string, v *Violation) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open policy log: %w", err)
+	}
+	defer f.Close()
+	user := os.Getenv("USER")
+	if user == "" {
+		user = os.Getenv("USERNAME")
+	}
+	line := fmt.Sprintf("%s user=%q command=%q version=%s policy=%q reason=%q\n",
+		time.Now().UTC().Format(time.RFC3339), user, command, strings.TrimPrefix(v.Version, "go"), v.Policy, v.Reason)
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("write policy log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		rng     string
+		version string
+		want    bool
+	}{
+		{"1.21", "go1.21.13", true},
+		{"1.21.x", "go1.21rc2", true},
+		{"1.21", "go1.22.0", false},
+		{"1.22.3", "go1.22.3", true},
+		{"1.22.3", "go1.22.4", false},
+		{">=1.22.0 <1.22.5", "go1.22.4", true},
+		{">=1.22.0 <1.22.5", "go1.22.5", false},
+		{">1.20.14", "go1.21.0", true},
+		{"<=1.20.14", "go1.20.14", true},
+		{"=1.23rc1", "go1.23rc1", true},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.rng)
+		if err != nil {
+			t.Fatalf("ParseRange(%q): %v", tt.rng, err)
+		}
+		v, err := gover.Parse(tt.version)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.version, err)
+		}
+		if got := r.Contains(v); got != tt.want {
+			t.Errorf("%q contains %s = %v, want %v", tt.rng, tt.version, got, tt.want)
+		}
+	}
+	for _, s := range []string{"", "latest", ">=1.21.x", "~1.21.0"} {
+		if _, err := ParseRange(s); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("ParseRange(%q): err = %v, want ErrInvalidRange", s, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := &Policy{
+		Minimum:           "1.21.0",
+		Allow:             []string{"1.21", "1.22"},
+		Deny:              []string{">=1.22.0 <1.22.2"},
+		ForbidUnsupported: true,
+		ForbidVulnerable:  true,
+		Path:              "/org/policy.json",
+	}
+	facts := Facts{
+		Catalog: &toolchain.Catalog{Releases: []toolchain.Release{
+			{Version: "go1.23.1", Stable: true},
+			{Version: "go1.22.5", Stable: true},
+			{Version: "go1.21.13", Stable: true},
+		}},
+		Vulnerabilities: func(version string) ([]string, error) {
+			if version == "go1.22.3" {
+				return []string{"GO-2024-2887"}, nil
+			}
+			return nil, nil
+		},
+	}
+	tests := map[string]string{
+		"go1.22.5":  "",
+		"go1.20.14": "older than minimum version 1.21.0",
+		"go1.22.1":  `denied by range ">=1.22.0 <1.22.2"`,
+		"go1.23.1":  "not in allowed ranges",
+		"go1.21.13": "1.21 is no longer supported",
+		"go1.22.3":  "known vulnerabilities GO-2024-2887",
+		"gotip-abc": "not a Go release",
+	}
+	for version, want := range tests {
+		err := p.Check(version, facts)
+		if want == "" {
+			if err != nil {
+				t.Errorf("Check(%s) = %v, want nil", version, err)
+			}
+			continue
+		}
+		var v *Violation
+		if !errors.As(err, &v) || !strings.Contains(v.Reason, want) {
+			t.Errorf("Check(%s) = %v, want violation %q", version, err, want)
+			continue
+		}
+		if !strings.Contains(err.Error(), p.Path) {
+			t.Errorf("error %q does not cite policy %s", err, p.Path)
+		}
+	}
+
+	// rules needing data fail closed without it
+	var v *Violation
+	if err := p.Check("go1.22.5", Facts{}); !errors.As(err, &v) || !strings.Contains(v.Reason, "release catalog is not available") {
+		t.Errorf("Check without catalog = %v, want violation", err)
+	}
+	facts.Vulnerabilities = nil
+	if err := p.Check("go1.22.5", facts); !errors.As(err, &v) || !strings.Contains(v.Reason, "vulnerabilities can not be checked") {
+		t.Errorf("Check without vulnerability database = %v, want violation", err)
+	}
+	facts.Vulnerabilities = func(string) ([]string, error) { return nil, errors.New("no cached database") }
+	if err := p.Check("go1.22.5", facts); !errors.As(err, &v) || !strings.Contains(v.Reason, "no cached database") {
+		t.Errorf("Check with failing lookup = %v, want violation", err)
+	}
+}
+
+func TestLoadAndFind(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if file, err := Find(sub); err != nil || file != "" {
+		t.Fatalf("Find (no policy) = %q, %v", file, err)
+	}
+
+	file := filepath.Join(root, File)
+	if err := os.WriteFile(file, []byte(`{"minimum": "1.22", "deny": ["1.22.0"]}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	found, err := Find(sub)
+	if err != nil || found != file {
+		t.Fatalf("Find = %q, %v, want %q", found, err, file)
+	}
+	p, err := Load(found)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p.Minimum != "1.22" || p.Path != file {
+		t.Errorf("policy = %+v", p)
+	}
+
+	if err := os.WriteFile(file, []byte(`{"deny": ["newest"]}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(file); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Load: err = %v, want ErrInvalidRange", err)
+	}
+}
+
+func TestLogOverride(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "logs", "policy.log")
+	v := &Violation{Version: "go1.20.1", Policy: "/org/policy.json", Reason: "older than minimum version 1.21.0"}
+	for range 2 {
+		if err := LogOverride(file, "use", v); err != nil {
+			t.Fatalf("LogOverride: %v", err)
+		}
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `command="use" version=1.20.1 policy="/org/policy.json"`) {
+		t.Errorf("log = %q", data)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// LockFile lists Go toolchains required by repository with expected
+// SHA-256 checksums of their release archives, one line per platform:
+//
+//	go1.22.4 linux/amd64 <sha256>
+const LockFile = "gm.lock"
+
+const lockHeader = "# Go toolchains required by this repository.\n# Generated by \"gm lock\", installed and verified by \"gm sync\".\n"
+
+// LockEntry is a checksum of release archive of Go version for platform.
+type LockEntry struct {
+	// Version with "go" prefix
+	Version string
+	// Platform is GOOS/GOARCH
+	Platform string
+	SHA256   string
+}
+
+// Lock is a content of lock file.
+type Lock struct {
+	Entries []LockEntry
+	// Dir is directory containing lock file
+	Dir string
+}
+
+// ReadLock reads lock file in dir. It returns nil if there is no lock file.
+func ReadLock(dir string) (*Lock, error) {
+	data, err := readFile(dir, LockFile)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	l := &Lock{Dir: dir}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !gover.IsValid(fields[0]) || !strings.Contains(fields[1], "/") {
+			return nil, fmt.Errorf("%s:%d: malformed line %q", LockFile, n, line)
+		}
+		l.Set(fields[0], fields[1], fields[2])
+	}
+	return l, nil
+}
+
+// FindLock returns lock file of repository dir belongs to, looking in dir and its parents.
+// It returns nil if there is no lock file.
+func FindLock(dir string) (*Lock, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		l, err := ReadLock(dir)
+		if err != nil || l != nil {
+			return l, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Versions returns locked Go versions, newest first.
+func (l *Lock) Versions() []string {
+	var versions []string
+	for _, e := range l.Entries {
+		if !slices.Contains(versions, e.Version) {
+			versions = append(versions, e.Version)
+		}
+	}
+	gover.SortDesc(versions, func(v string) string { return v })
+	return versions
+}
+
+// Platforms returns platforms of locked checksums.
+func (l *Lock) Platforms() []string {
+	var platforms []string
+	for _, e := range l.Entries {
+		if !slices.Contains(platforms, e.Platform) {
+			platforms = append(platforms, e.Platform)
+		}
+	}
+	slices.Sort(platforms)
+	return platforms
+}
+
+// Checksum returns expected checksum of release archive of version for platform.
+func (l *Lock) Checksum(version, platform string) (string, bool) {
+	version = "go" + strings.TrimPrefix(version, "go")
+	for _, e := range l.Entries {
+		if e.Version == version && e.Platform == platform {
+			return e.SHA256, true
+		}
+	}
+	return "", false
+}
+
+// Set records checksum of release archive of version for platform.
+func (l *Lock) Set(version, platform, sha256 string) {
+	version = "go" + strings.TrimPrefix(version, "go")
+	for i, e := range l.Entries {
+		if e.Version == version && e.Platform == platform {
+			l.Entries[i].SHA256 = sha256
+			return
+		}
+	}
+	l.Entries = append(l.Entries, LockEntry{Version: version, Platform: platform, SHA256: sha256})
+}
+
+// Write writes lock file into Dir, entries are sorted by version, newest first.
+func (l *Lock) Write() error {
+	entries := slices.Clone(l.Entries)
+	slices.SortStableFunc(entries, func(a, b LockEntry) int {
+		if c := gover.Compare(b.Version, a.Version); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Platform, b.Platform)
+	})
+	var b strings.Builder
+	b.WriteString(lockHeader)
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s %s %s\n", e.Version, e.Platform, e.SHA256)
+	}
+	return writeFile(filepath.Join(l.Dir, LockFile), []byte(b.String()))
+}
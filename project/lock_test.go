@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLockRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	l := &Lock{Dir: root}
+	l.Set("1.20.14", "linux/amd64", "aaa")
+	l.Set("go1.22.4", "linux/amd64", "bbb")
+	l.Set("go1.22.4", "darwin/arm64", "ccc")
+	l.Set("go1.23rc1", "linux/amd64", "ddd")
+	l.Set("go1.22.4", "linux/amd64", "eee")
+	if err := l.Write(); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := lockHeader +
+		"go1.23rc1 linux/amd64 ddd\n" +
+		"go1.22.4 darwin/arm64 ccc\n" +
+		"go1.22.4 linux/amd64 eee\n" +
+		"go1.20.14 linux/amd64 aaa\n"
+	if got := readProjectFile(t, root, LockFile); got != want {
+		t.Errorf("%s = %q, want %q", LockFile, got, want)
+	}
+
+	sub := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	found, err := FindLock(sub)
+	if err != nil {
+		t.Fatalf("FindLock: %v", err)
+	}
+	if found == nil || found.Dir != root {
+		t.Fatalf("FindLock = %+v, want lock in %s", found, root)
+	}
+	if got, want := found.Versions(), []string{"go1.23rc1", "go1.22.4", "go1.20.14"}; !slices.Equal(got, want) {
+		t.Errorf("Versions = %v, want %v", got, want)
+	}
+	if got, want := found.Platforms(), []string{"darwin/arm64", "linux/amd64"}; !slices.Equal(got, want) {
+		t.Errorf("Platforms = %v, want %v", got, want)
+	}
+	if sum, ok := found.Checksum("1.22.4", "darwin/arm64"); !ok || sum != "ccc" {
+		t.Errorf("Checksum = %q, %v, want ccc", sum, ok)
+	}
+	if _, ok := found.Checksum("go1.20.14", "darwin/arm64"); ok {
+		t.Error("Checksum: want no checksum for darwin/arm64")
+	}
+}
+
+func TestReadLock(t *testing.T) {
+	dir := t.TempDir()
+	l, err := ReadLock(dir)
+	if err != nil || l != nil {
+		t.Fatalf("ReadLock (no file) = %+v, %v", l, err)
+	}
+	writeProjectFile(t, dir, LockFile, "# comment\n\ngo1.22.4 linux-amd64\n")
+	if _, err := ReadLock(dir); err == nil {
+		t.Error("ReadLock: want error for malformed line")
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// Write pins version for project in dir using format f.
+// Other entries of .tool-versions and formatting of go.mod are kept.
+func Write(dir, version string, f Format) error {
+	v, err := gover.Parse(version)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, f.File())
+
+	switch f {
+	case FormatGoVersion:
+		return writeFile(path, []byte(strings.TrimPrefix(v.String(), "go")+"\n"))
+	case FormatToolVersions:
+		data, err := readFile(dir, toolVersionsFile)
+		if err != nil {
+			return err
+		}
+		entry := "golang " + strings.TrimPrefix(v.String(), "go")
+		lines, replaced := editToolVersions(data, &entry)
+		if !replaced {
+			lines = append(lines, entry)
+		}
+		return writeFile(path, []byte(strings.Join(lines, "\n")+"\n"))
+	case FormatGoMod:
+		data, err := readFile(dir, goModFile)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			return fmt.Errorf("%s has no %s", dir, goModFile)
+		}
+		mf, err := modfile.Parse(path, data, nil)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", goModFile, err)
+		}
+		if mf.Go != nil && gover.Compare(v.String(), "go"+mf.Go.Version) < 0 {
+			return fmt.Errorf("toolchain %s is older than go %s required by %s", v, mf.Go.Version, goModFile)
+		}
+		if err := mf.AddToolchainStmt(v.String()); err != nil {
+			return fmt.Errorf("set toolchain directive: %w", err)
+		}
+		return writeModFile(path, mf)
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownFormat, f)
+	}
+}
+
+// Remove removes Go version pins of project in dir in all formats
+// and returns formats which were removed.
+func Remove(dir string) ([]Format, error) {
+	var removed []Format
+	for _, f := range formats {
+		version, err := readPin(dir, f)
+		if err != nil {
+			return removed, err
+		}
+		if version == "" {
+			continue
+		}
+		path := filepath.Join(dir, f.File())
+
+		switch f {
+		case FormatGoVersion:
+			err = os.Remove(path)
+		case FormatToolVersions:
+			data, rerr := readFile(dir, toolVersionsFile)
+			if rerr != nil {
+				return removed, rerr
+			}
+			lines, _ := editToolVersions(data, nil)
+			if len(lines) == 0 {
+				err = os.Remove(path)
+			} else {
+				err = writeFile(path, []byte(strings.Join(lines, "\n")+"\n"))
+			}
+		case FormatGoMod:
+			data, rerr := readFile(dir, goModFile)
+			if rerr != nil {
+				return removed, rerr
+			}
+			mf, perr := modfile.Parse(path, data, nil)
+			if perr != nil {
+				return removed, fmt.Errorf("parse %s: %w", goModFile, perr)
+			}
+			mf.DropToolchainStmt()
+			err = writeModFile(path, mf)
+		}
+		if err != nil {
+			return removed, fmt.Errorf("remove pin from %s: %w", f.File(), err)
+		}
+		removed = append(removed, f)
+	}
+	return removed, nil
+}
+
+// editToolVersions replaces Go entry of .tool-versions with entry,
+// or removes it if entry is nil. It reports whether Go entry was found.
+func editToolVersions(data []byte, entry *string) ([]string, bool) {
+	var lines []string
+	found := false
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if _, ok := toolVersionsEntry(line); ok && !found {
+			found = true
+			if entry != nil {
+				lines = append(lines, *entry)
+			}
+			continue
+		}
+		if line != "" || len(lines) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines, found
+}
+
+func writeModFile(path string, mf *modfile.File) error {
+	mf.Cleanup()
+	data, err := mf.Format()
+	if err != nil {
+		return fmt.Errorf("format %s: %w", goModFile, err)
+	}
+	return writeFile(path, data)
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeProjectFile(t *testing.T, dir, name, data string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func readProjectFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return string(data)
+}
+
+func TestWriteGoVersion(t *testing.T) {
+	dir := t.TempDir()
+	if err := Write(dir, "go1.22.4", FormatGoVersion); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readProjectFile(t, dir, ".go-version"); got != "1.22.4\n" {
+		t.Errorf(".go-version = %q", got)
+	}
+	if err := Write(dir, "not-a-version", FormatGoVersion); err == nil {
+		t.Error("Write: want error for invalid version")
+	}
+}
+
+func TestWriteToolVersions(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectFile(t, dir, ".tool-versions", "nodejs 20.1.0\n")
+	if err := Write(dir, "go1.21.13", FormatToolVersions); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got, want := readProjectFile(t, dir, ".tool-versions"), "nodejs 20.1.0\ngolang 1.21.13\n"; got != want {
+		t.Errorf(".tool-versions = %q, want %q", got, want)
+	}
+
+	writeProjectFile(t, dir, ".tool-versions", "golang 1.20.1\nnodejs 20.1.0\n")
+	if err := Write(dir, "1.22.4", FormatToolVersions); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got, want := readProjectFile(t, dir, ".tool-versions"), "golang 1.22.4\nnodejs 20.1.0\n"; got != want {
+		t.Errorf(".tool-versions = %q, want %q", got, want)
+	}
+}
+
+func TestWriteGoMod(t *testing.T) {
+	dir := t.TempDir()
+	if err := Write(dir, "go1.22.4", FormatGoMod); err == nil {
+		t.Error("Write: want error for project without go.mod")
+	}
+
+	gomod := "module example.com/app\n\n// minimum version\ngo 1.21\n\nrequire golang.org/x/mod v0.20.0 // indirect\n"
+	writeProjectFile(t, dir, "go.mod", gomod)
+	if err := Write(dir, "go1.22.4", FormatGoMod); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := "module example.com/app\n\n// minimum version\ngo 1.21\n\ntoolchain go1.22.4\n\nrequire golang.org/x/mod v0.20.0 // indirect\n"
+	if got := readProjectFile(t, dir, "go.mod"); got != want {
+		t.Errorf("go.mod = %q, want %q", got, want)
+	}
+
+	if err := Write(dir, "go1.20.14", FormatGoMod); err == nil {
+		t.Error("Write: want error for toolchain older than go directive")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	removed, err := Remove(dir)
+	if err != nil || len(removed) != 0 {
+		t.Fatalf("Remove (no pins) = %v, %v", removed, err)
+	}
+
+	writeProjectFile(t, dir, ".go-version", "1.22.4\n")
+	writeProjectFile(t, dir, ".tool-versions", "golang 1.22.4\nnodejs 20.1.0\n")
+	writeProjectFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.21\n\ntoolchain go1.22.4\n")
+	removed, err = Remove(dir)
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if len(removed) != 3 {
+		t.Errorf("removed = %v, want all formats", removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".go-version")); !os.IsNotExist(err) {
+		t.Errorf(".go-version exists after Remove")
+	}
+	if got, want := readProjectFile(t, dir, ".tool-versions"), "nodejs 20.1.0\n"; got != want {
+		t.Errorf(".tool-versions = %q, want %q", got, want)
+	}
+	if got, want := readProjectFile(t, dir, "go.mod"), "module example.com/app\n\ngo 1.21\n"; got != want {
+		t.Errorf("go.mod = %q, want %q", got, want)
+	}
+	pinned, err := Pinned(dir)
+	if err != nil || len(pinned) != 0 {
+		t.Errorf("Pinned after Remove = %v, %v", pinned, err)
+	}
+
+	writeProjectFile(t, dir, ".tool-versions", "golang 1.22.4\n")
+	if _, err := Remove(dir); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".tool-versions")); !os.IsNotExist(err) {
+		t.Errorf("empty .tool-versions exists after Remove")
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+const (
+	goVersionFile    = ".go-version"
+	toolVersionsFile = ".tool-versions"
+	goModFile        = "go.mod"
+)
+
+// Format is a way of pinning Go version in project.
+type Format string
+
+const (
+	// FormatGoVersion pins version in .go-version file
+	FormatGoVersion Format = "go-version"
+	// FormatToolVersions pins version in golang entry of .tool-versions file (asdf, mise)
+	FormatToolVersions Format = "tool-versions"
+	// FormatGoMod pins version with toolchain directive of go.mod
+	FormatGoMod Format = "go.mod"
+)
+
+// formats are ordered by precedence, the first pin found in project directory is used.
+var formats = []Format{FormatGoVersion, FormatToolVersions, FormatGoMod}
+
+var ErrUnknownFormat = errors.New("unknown pin format")
+
+// ParseFormat validates pin format given by user.
+func ParseFormat(s string) (Format, error) {
+	if f := Format(s); slices.Contains(formats, f) {
+		return f, nil
+	}
+	return "", fmt.Errorf("%w %q (supported: go-version, tool-versions, go.mod)", ErrUnknownFormat, s)
+}
+
+// File returns name of the file storing pin of format.
+func (f Format) File() string {
+	switch f {
+	case FormatToolVersions:
+		return toolVersionsFile
+	case FormatGoMod:
+		return goModFile
+	default:
+		return goVersionFile
+	}
+}
+
+// Pin is a Go version pinned by project.
+type Pin struct {
+	// Version with "go" prefix
+	Version string
+	Format  Format
+	// Dir is project directory containing the pin
+	Dir string
+}
+
+// File returns path of the file storing pin.
+func (p Pin) File() string {
+	return filepath.Join(p.Dir, p.Format.File())
+}
+
+// Pinned returns Go versions pinned by project in dir: version in .go-version,
+// golang entry of .tool-versions and toolchain directive of go.mod.
+// Versions are returned with "go" prefix, without duplicates.
+func Pinned(dir string) ([]string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	pins, err := readPins(dir)
+	if err != nil {
+		return nil, err
+	}
+	var pinned []string
+	for _, p := range pins {
+		if !slices.Contains(pinned, p.Version) {
+			pinned = append(pinned, p.Version)
+		}
+	}
+	return pinned, nil
+}
+
+// Find returns pin of the project dir belongs to, looking in dir and its parents.
+// It returns nil if no project pins Go version.
+func Find(dir string) (*Pin, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		pins, err := readPins(dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(pins) > 0 {
+			return &pins[0], nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// readPins returns pins found in dir in order of precedence.
+func readPins(dir string) ([]Pin, error) {
+	var pins []Pin
+	for _, f := range formats {
+		version, err := readPin(dir, f)
+		if err != nil {
+			return nil, err
+		}
+		if version != "" {
+			pins = append(pins, Pin{Version: version, Format: f, Dir: dir})
+		}
+	}
+	return pins, nil
+}
+
+// readPin returns version pinned in dir in the given format, empty if there is none.
+func readPin(dir string, f Format) (string, error) {
+	data, err := readFile(dir, f.File())
+	if err != nil || data == nil {
+		return "", err
+	}
+
+	var version string
+	switch f {
+	case FormatGoVersion:
+		line, _, _ := bytes.Cut(data, []byte("\n"))
+		version = string(line)
+	case FormatToolVersions:
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		for sc.Scan() {
+			if v, ok := toolVersionsEntry(sc.Text()); ok {
+				version = v
+				break
+			}
+		}
+	case FormatGoMod:
+		// lax parsing skips toolchain directive
+		mf, err := modfile.Parse(filepath.Join(dir, goModFile), data, nil)
+		if err != nil {
+			return "", fmt.Errorf("parse %s: %w", goModFile, err)
+		}
+		if mf.Toolchain != nil {
+			version = mf.Toolchain.Name
+		}
+	}
+
+	version = "go" + strings.TrimPrefix(strings.TrimSpace(version), "go")
+	if !gover.IsValid(version) {
+		return "", nil
+	}
+	return version, nil
+}
+
+// toolVersionsEntry returns Go version of .tool-versions line.
+func toolVersionsEntry(line string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) >= 2 && (fields[0] == "golang" || fields[0] == "go") {
+		return fields[1], true
+	}
+	return "", false
+}
+
+// readFile returns contents of project file, nil if it does not exist.
+func readFile(dir, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPinned(t *testing.T) {
+	dir := t.TempDir()
+	pinned, err := Pinned(dir)
+	if err != nil {
+		t.Fatalf("Pinned (empty project): %v", err)
+	}
+	if len(pinned) != 0 {
+		t.Errorf("pinned = %v, want none", pinned)
+	}
+
+	if _, err := Pinned(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Pinned: want error for missing project directory")
+	}
+
+	files := map[string]string{
+		".go-version":    "1.22.4\n",
+		".tool-versions": "nodejs 20.1.0\ngolang 1.21.13\n",
+		"go.mod":         "module example.com/app\n\ngo 1.21\n\ntoolchain go1.23.1\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	pinned, err = Pinned(dir)
+	if err != nil {
+		t.Fatalf("Pinned: %v", err)
+	}
+	if want := []string{"go1.22.4", "go1.21.13", "go1.23.1"}; !slices.Equal(pinned, want) {
+		t.Errorf("pinned = %v, want %v", pinned, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "cmd", "app")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	pin, err := Find(sub)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if pin != nil {
+		t.Fatalf("pin = %+v, want none", pin)
+	}
+
+	files := map[string]string{
+		".tool-versions": "golang 1.21.13\n",
+		"go.mod":         "module example.com/app\n\ngo 1.21\n\ntoolchain go1.22.4\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(data), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	pin, err = Find(sub)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	want := Pin{Version: "go1.21.13", Format: FormatToolVersions, Dir: root}
+	if pin == nil || *pin != want {
+		t.Errorf("pin = %+v, want %+v", pin, want)
+	}
+	if pin != nil && pin.File() != filepath.Join(root, ".tool-versions") {
+		t.Errorf("File() = %q", pin.File())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, s := range []string{"go-version", "tool-versions", "go.mod"} {
+		if f, err := ParseFormat(s); err != nil || string(f) != s {
+			t.Errorf("ParseFormat(%q) = %q, %v", s, f, err)
+		}
+	}
+	if _, err := ParseFormat("gomod"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("ParseFormat: err = %v, want ErrUnknownFormat", err)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+const goWorkFile = "go.work"
+
+// skipDirs are never scanned: vendored dependencies, JavaScript packages and test fixtures.
+var skipDirs = map[string]bool{"vendor": true, "node_modules": true, "testdata": true}
+
+// Requirement is a Go version required by project file.
+type Requirement struct {
+	// Version with "go" prefix
+	Version string
+	// Minimum is set for go directive of go.mod and go.work, any newer version satisfies it
+	Minimum bool
+	// File is a path of the file with requirement
+	File string
+}
+
+// ScanResult holds requirements found by Scan.
+type ScanResult struct {
+	Requirements []Requirement
+	// Problems are files which could not be read
+	Problems []error
+}
+
+// Scan walks directory trees of roots and collects Go versions required by go.mod
+// and go.work files (go and toolchain directives), .go-version and .tool-versions.
+// Hidden directories, vendor, node_modules and testdata are skipped.
+func Scan(roots []string) (*ScanResult, error) {
+	res := &ScanResult{}
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == root {
+					return err
+				}
+				res.Problems = append(res.Problems, err)
+				return nil
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if path != root && (skipDirs[name] || strings.HasPrefix(name, ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if err := res.scanFile(path, d.Name()); err != nil {
+				res.Problems = append(res.Problems, fmt.Errorf("%s: %w", path, err))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan %s: %w", root, err)
+		}
+	}
+	return res, nil
+}
+
+func (res *ScanResult) scanFile(path, name string) error {
+	dir := filepath.Dir(path)
+	switch name {
+	case goVersionFile, toolVersionsFile:
+		f := FormatGoVersion
+		if name == toolVersionsFile {
+			f = FormatToolVersions
+		}
+		version, err := readPin(dir, f)
+		if err != nil || version == "" {
+			return err
+		}
+		res.add(version, false, path)
+	case goModFile:
+		data, err := readFile(dir, name)
+		if err != nil {
+			return err
+		}
+		mf, err := modfile.Parse(path, data, nil)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		if mf.Go != nil {
+			res.add(mf.Go.Version, true, path)
+		}
+		if mf.Toolchain != nil {
+			res.add(mf.Toolchain.Name, false, path)
+		}
+	case goWorkFile:
+		data, err := readFile(dir, name)
+		if err != nil {
+			return err
+		}
+		wf, err := modfile.ParseWork(path, data, nil)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		if wf.Go != nil {
+			res.add(wf.Go.Version, true, path)
+		}
+		if wf.Toolchain != nil {
+			res.add(wf.Toolchain.Name, false, path)
+		}
+	}
+	return nil
+}
+
+func (res *ScanResult) add(version string, minimum bool, path string) {
+	version = "go" + strings.TrimPrefix(version, "go")
+	if !gover.IsValid(version) {
+		// e.g. "toolchain default"
+		return
+	}
+	res.Requirements = append(res.Requirements, Requirement{Version: version, Minimum: minimum, File: path})
+}
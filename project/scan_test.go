@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"svc/go.mod":                    "module example.com/svc\n\ngo 1.22.1\n\ntoolchain go1.22.4\n",
+		"svc/vendor/x/go.mod":           "module x\n\ngo 1.10\n",
+		"web/node_modules/.go-version":  "1.9.0\n",
+		"legacy/.go-version":            "1.20.14\n",
+		"legacy/.git/go.mod":            "module git\n\ngo 1.11\n",
+		"tools/.tool-versions":          "nodejs 20.1.0\ngolang 1.21.13\n",
+		"mono/go.work":                  "go 1.23\n\nuse ./a\n",
+		"mono/a/testdata/broken/go.mod": "not a go.mod",
+		"broken/go.mod":                 "module broken\n\nunknown directive\n",
+	}
+	for name, data := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	res, err := Scan([]string{root})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	var got []string
+	for _, r := range res.Requirements {
+		rel, _ := filepath.Rel(root, r.File)
+		kind := "="
+		if r.Minimum {
+			kind = ">="
+		}
+		got = append(got, filepath.ToSlash(rel)+" "+kind+r.Version)
+	}
+	slices.Sort(got)
+	want := []string{
+		"legacy/.go-version =go1.20.14",
+		"mono/go.work >=go1.23",
+		"svc/go.mod =go1.22.4",
+		"svc/go.mod >=go1.22.1",
+		"tools/.tool-versions =go1.21.13",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("requirements = %q, want %q", got, want)
+	}
+	if len(res.Problems) != 1 {
+		t.Errorf("problems = %v, want broken/go.mod", res.Problems)
+	}
+
+	if _, err := Scan([]string{filepath.Join(root, "missing")}); err == nil {
+		t.Error("Scan: want error for missing root")
+	}
+}
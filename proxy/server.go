@@ -0,0 +1,249 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// DefaultAddr is address the proxy listens on by default.
+const DefaultAddr = "127.0.0.1:7871"
+
+// DefaultUpstream is module proxy receiving requests for modules other than toolchains.
+const DefaultUpstream = "https://proxy.golang.org"
+
+// Config describes toolchain proxy.
+type Config struct {
+	// Toolchains returns toolchains available for serving.
+	Toolchains func() ([]sys.Toolchain, error)
+	// CacheDir is a directory for module zips built from toolchains.
+	CacheDir string
+	// Upstream is URL of module proxy serving all other requests,
+	// "off" or empty string to respond with "not found".
+	Upstream string
+	// ErrorLog receives errors of building responses, if set.
+	ErrorLog *log.Logger
+}
+
+// Server is a GOPROXY serving golang.org/toolchain module from installed toolchains.
+type Server struct {
+	cfg      Config
+	upstream *httputil.ReverseProxy
+
+	// mu serializes building of module zips
+	mu sync.Mutex
+}
+
+// New creates toolchain proxy.
+func New(cfg Config) (*Server, error) {
+	s := &Server{cfg: cfg}
+	if cfg.Upstream != "" && cfg.Upstream != "off" {
+		target, err := url.Parse(cfg.Upstream)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return nil, fmt.Errorf("invalid upstream proxy URL %q", cfg.Upstream)
+		}
+		s.upstream = &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(target)
+			},
+			ErrorLog: cfg.ErrorLog,
+		}
+	}
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	file, ok := strings.CutPrefix(r.URL.Path, "/"+toolchain.ToolchainModule+"/@v/")
+	if !ok {
+		s.forward(w, r)
+		return
+	}
+	if file == "list" {
+		s.serveList(w, r)
+		return
+	}
+
+	ext := filepath.Ext(file)
+	goroot, ok, err := s.lookup(strings.TrimSuffix(file, ext))
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	if !ok {
+		s.forward(w, r)
+		return
+	}
+	modVersion := strings.TrimSuffix(file, ext)
+
+	switch ext {
+	case ".info":
+		s.serveInfo(w, goroot, modVersion)
+	case ".mod":
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+		io.WriteString(w, "module "+toolchain.ToolchainModule+"\n")
+	case ".zip":
+		s.serveZip(w, r, goroot, modVersion)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveList lists versions of toolchain module available locally.
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request) {
+	toolchains, err := s.cfg.Toolchains()
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	var list strings.Builder
+	for _, tc := range toolchains {
+		if servable(tc) {
+			list.WriteString(toolchain.ModuleVersion(releaseVersion(tc)).Version + "\n")
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	io.WriteString(w, list.String())
+}
+
+func (s *Server) serveInfo(w http.ResponseWriter, goroot, modVersion string) {
+	info := struct {
+		Version string
+		Time    time.Time
+	}{Version: modVersion}
+	if fi, err := os.Stat(filepath.Join(goroot, "VERSION")); err == nil {
+		info.Time = fi.ModTime().UTC()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
+
+func (s *Server) serveZip(w http.ResponseWriter, r *http.Request, goroot, modVersion string) {
+	zipFile, err := s.buildZip(goroot, modVersion)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	f, err := os.Open(zipFile)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	http.ServeContent(w, r, filepath.Base(zipFile), fi.ModTime(), f)
+}
+
+// buildZip returns path of cached module zip of toolchain in goroot,
+// building it if toolchain was installed after zip was cached.
+func (s *Server) buildZip(goroot, modVersion string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	zipFile := filepath.Join(s.cfg.CacheDir, modVersion+".zip")
+	if fi, err := os.Stat(zipFile); err == nil && !fi.ModTime().Before(toolchain.InstallTime(goroot)) {
+		return zipFile, nil
+	}
+
+	if err := os.MkdirAll(s.cfg.CacheDir, 0755); err != nil {
+		return "", fmt.Errorf("create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.cfg.CacheDir, modVersion+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create cached zip: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	mod := module.Version{Path: toolchain.ToolchainModule, Version: modVersion}
+	if err := toolchain.CreateModuleZip(tmp, goroot, mod); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("write cached zip: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), zipFile); err != nil {
+		return "", fmt.Errorf("move cached zip: %w", err)
+	}
+	return zipFile, nil
+}
+
+// lookup returns GOROOT of installed toolchain for the given version of toolchain module.
+func (s *Server) lookup(modVersion string) (string, bool, error) {
+	v, ok := toolchain.ParseModuleVersion(modVersion)
+	if !ok {
+		return "", false, nil
+	}
+	toolchains, err := s.cfg.Toolchains()
+	if err != nil {
+		return "", false, err
+	}
+	for _, tc := range toolchains {
+		if releaseVersion(tc) == v && servable(tc) {
+			return tc.Path, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// forward passes request to upstream proxy.
+func (s *Server) forward(w http.ResponseWriter, r *http.Request) {
+	if s.upstream == nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.upstream.ServeHTTP(w, r)
+}
+
+func (s *Server) fail(w http.ResponseWriter, err error) {
+	if s.cfg.ErrorLog != nil {
+		s.cfg.ErrorLog.Print(err)
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// releaseVersion returns version of toolchain, as recorded at install time if possible.
+func releaseVersion(tc sys.Toolchain) string {
+	if tc.Record != nil && tc.Record.Version != "" {
+		return tc.Record.Version
+	}
+	return "go" + tc.Version
+}
+
+// servable reports whether toolchain is a complete installation of Go release.
+func servable(tc sys.Toolchain) bool {
+	if !gover.IsValid(releaseVersion(tc)) {
+		return false
+	}
+	if tc.External {
+		return toolchain.CheckTree(tc.Path) == nil
+	}
+	return toolchain.IsInstalled(tc.Path)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package proxy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/mod/sumdb/dirhash"
+
+	"github.com/x-dvr/gm/sys"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// writeToolchain creates installed toolchain of the given version in dir.
+func writeToolchain(t *testing.T, dir, version string) string {
+	t.Helper()
+	goroot := filepath.Join(dir, "go"+version)
+	files := map[string]string{
+		"VERSION":                     "go" + version,
+		"bin/go":                      "binary",
+		"src/go.mod":                  "module std\n",
+		"src/cmd/api/main.go":         "package main\n",
+		"go1.22.4.linux-amd64.tar.gz": "archive",
+		// left out of toolchain module by cmd/distpack
+		"api/go1.txt":            "pkg bufio, const MaxScanTokenSize = 65536\n",
+		"doc/go_spec.html":       "<!DOCTYPE html>\n",
+		"misc/wasm/wasm_exec.js": "\"use strict\";\n",
+		"test/run.go":            "// run\n",
+		"test/go.mod":            "module test\n",
+	}
+	for name, contents := range files {
+		p := filepath.Join(goroot, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := toolchain.MarkInstalled(goroot, toolchain.InstallRecord{}); err != nil {
+		t.Fatalf("MarkInstalled: %v", err)
+	}
+	return goroot
+}
+
+func newTestServer(t *testing.T, upstream string, toolchains ...sys.Toolchain) (*Server, string) {
+	t.Helper()
+	cacheDir := t.TempDir()
+	srv, err := New(Config{
+		Toolchains: func() ([]sys.Toolchain, error) { return toolchains, nil },
+		CacheDir:   cacheDir,
+		Upstream:   upstream,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return srv, cacheDir
+}
+
+func get(t *testing.T, h http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestServer_Toolchain(t *testing.T) {
+	dir := t.TempDir()
+	goroot := writeToolchain(t, dir, "1.22.4")
+	srv, cacheDir := newTestServer(t, "off",
+		sys.Toolchain{Version: "1.22.4", Path: goroot},
+		sys.Toolchain{Version: "tip-abc", Path: writeToolchain(t, dir, "tip-abc")},
+		sys.Toolchain{Version: "1.21.0", Path: filepath.Join(dir, "missing")},
+	)
+	mod := toolchain.ModuleVersion("go1.22.4")
+	base := "/" + mod.Path + "/@v/"
+
+	rec := get(t, srv, base+"list")
+	if rec.Code != http.StatusOK || rec.Body.String() != mod.Version+"\n" {
+		t.Errorf("list = %d %q, want only %s", rec.Code, rec.Body.String(), mod.Version)
+	}
+
+	rec = get(t, srv, base+mod.Version+".info")
+	var info struct{ Version string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil || info.Version != mod.Version {
+		t.Errorf("info = %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = get(t, srv, base+mod.Version+".mod")
+	if rec.Body.String() != "module golang.org/toolchain\n" {
+		t.Errorf("mod = %q", rec.Body.String())
+	}
+
+	rec = get(t, srv, base+mod.Version+".zip")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("zip = %d %s", rec.Code, rec.Body.String())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("read zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, strings.TrimPrefix(f.Name, mod.Path+"@"+mod.Version+"/"))
+	}
+	slices.Sort(names)
+	if want := []string{"VERSION", "bin/go", "src/_go.mod", "src/cmd/api/main.go"}; !slices.Equal(names, want) {
+		t.Errorf("zip files = %v, want %v", names, want)
+	}
+
+	// served zip must have hash published in checksum database
+	zipFile := filepath.Join(t.TempDir(), "served.zip")
+	if err := os.WriteFile(zipFile, rec.Body.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := dirhash.HashZip(zipFile, dirhash.Hash1)
+	if err != nil {
+		t.Fatalf("HashZip: %v", err)
+	}
+	published := map[string]string{
+		"VERSION":             "go1.22.4",
+		"bin/go":              "binary",
+		"src/_go.mod":         "module std\n",
+		"src/cmd/api/main.go": "package main\n",
+	}
+	prefix := mod.Path + "@" + mod.Version + "/"
+	var files []string
+	for name := range published {
+		files = append(files, prefix+name)
+	}
+	want, err := dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(published[strings.TrimPrefix(name, prefix)])), nil
+	})
+	if err != nil {
+		t.Fatalf("Hash1: %v", err)
+	}
+	if runtime.GOOS == "linux" && runtime.GOARCH == "amd64" {
+		// value for golang.org/toolchain@v0.0.1-go1.22.4.linux-amd64
+		want = "h1:Z5hKhV+JVnsUQNaapa/UcAvT5nxFn4GhP0hOUuO0dc4="
+	}
+	if got != want {
+		t.Errorf("hash of served zip = %s, want %s", got, want)
+	}
+
+	cached := filepath.Join(cacheDir, mod.Version+".zip")
+	if _, err := os.Stat(cached); err != nil {
+		t.Fatalf("zip must be cached: %v", err)
+	}
+
+	// reinstalled toolchain invalidates cache
+	built := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(cached, built, built); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if rec := get(t, srv, base+mod.Version+".zip"); rec.Code != http.StatusOK {
+		t.Fatalf("zip = %d", rec.Code)
+	}
+	if fi, err := os.Stat(cached); err != nil || !fi.ModTime().After(built) {
+		t.Errorf("cached zip must be rebuilt")
+	}
+}
+
+func TestServer_Upstream(t *testing.T) {
+	var requested []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		io.WriteString(w, "upstream")
+	}))
+	defer upstream.Close()
+
+	srv, _ := newTestServer(t, upstream.URL+"/prefix")
+	missing := "/golang.org/toolchain/@v/" + toolchain.ModuleVersion("go1.22.4").Version + ".zip"
+	for _, path := range []string{"/github.com/spf13/cobra/@v/list", "/sumdb/sum.golang.org/supported", missing} {
+		rec := get(t, srv, path)
+		if rec.Code != http.StatusOK || rec.Body.String() != "upstream" {
+			t.Errorf("GET %s = %d %q, want upstream response", path, rec.Code, rec.Body.String())
+		}
+	}
+	if len(requested) != 3 || requested[0] != "/prefix/github.com/spf13/cobra/@v/list" {
+		t.Errorf("upstream requests = %v", requested)
+	}
+
+	off, _ := newTestServer(t, "off")
+	if rec := get(t, off, missing); rec.Code != http.StatusNotFound {
+		t.Errorf("GET %s without upstream = %d, want 404", missing, rec.Code)
+	}
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	srv, _ := newTestServer(t, "off")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/golang.org/toolchain/@v/list", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want 405", rec.Code)
+	}
+}
+
+func TestNew_InvalidUpstream(t *testing.T) {
+	if _, err := New(Config{Upstream: "proxy.example"}); err == nil {
+		t.Error("upstream without scheme must be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// GoRoot returns GOROOT of installed version, or GOROOT of current version if version is empty.
+func GoRoot(version string) (string, error) {
+	if version == "" {
+		version = current
+	}
+	goRoot, err := PathForVersion(version)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(goRoot); err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrNotInstalled
+		}
+		return "", fmt.Errorf("check installed version: %w", err)
+	}
+	return goRoot, nil
+}
+
+// ToolchainEnv returns environ with GOROOT set to goRoot and its bin directory
+// put first in PATH. Bin directories of other toolchains managed by gm are
+// removed from PATH, so the result does not grow when applied repeatedly.
+// Empty goRoot removes toolchains managed by gm from environment.
+func ToolchainEnv(goRoot string, environ []string) ([]string, error) {
+	versionsPath, err := PathForVersion("")
+	if err != nil {
+		return nil, err
+	}
+	env := make([]string, 0, len(environ)+2)
+	path := ""
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		switch {
+		case envNameIs(name, "PATH"):
+			path = value
+		case envNameIs(name, "GOROOT"):
+		default:
+			env = append(env, kv)
+		}
+	}
+	if goRoot != "" {
+		env = append(env, "GOROOT="+goRoot)
+	}
+	return append(env, "PATH="+ActivePath(goRoot, path, versionsPath)), nil
+}
+
+// ActivePath returns path list with bin directory of goRoot first and
+// entries inside versionsPath removed. Empty goRoot is not added.
+func ActivePath(goRoot, path, versionsPath string) string {
+	var entries []string
+	if goRoot != "" {
+		entries = append(entries, filepath.Join(goRoot, "bin"))
+	}
+	prefix := filepath.Clean(versionsPath) + string(filepath.Separator)
+	for _, entry := range filepath.SplitList(path) {
+		if entry == "" || strings.HasPrefix(filepath.Clean(entry), prefix) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, string(os.PathListSeparator))
+}
+
+// envNameIs compares environment variable names, names are case-insensitive on Windows.
+func envNameIs(name, want string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(name, want)
+	}
+	return name == want
+}
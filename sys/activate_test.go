@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestActivePath(t *testing.T) {
+	versionsPath := filepath.Join("/home", "u", gmDir, versions)
+	goRoot := filepath.Join(versionsPath, "go1.22.4")
+	path := strings.Join([]string{
+		filepath.Join(versionsPath, current, "bin"),
+		"/usr/bin",
+		"",
+		filepath.Join("/home", "u", gmDir, workspace, "bin"),
+		filepath.Join(versionsPath, "go1.21.13", "bin"),
+	}, string(os.PathListSeparator))
+
+	got := ActivePath(goRoot, path, versionsPath)
+	want := strings.Join([]string{
+		filepath.Join(goRoot, "bin"),
+		"/usr/bin",
+		filepath.Join("/home", "u", gmDir, workspace, "bin"),
+	}, string(os.PathListSeparator))
+	if got != want {
+		t.Errorf("ActivePath = %q, want %q", got, want)
+	}
+	if again := ActivePath(goRoot, got, versionsPath); again != want {
+		t.Errorf("ActivePath applied twice = %q, want %q", again, want)
+	}
+	want = strings.Join([]string{"/usr/bin", filepath.Join("/home", "u", gmDir, workspace, "bin")}, string(os.PathListSeparator))
+	if got := ActivePath("", path, versionsPath); got != want {
+		t.Errorf("ActivePath (no toolchain) = %q, want %q", got, want)
+	}
+}
+
+func TestToolchainEnv(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	goRoot := filepath.Join(home, gmDir, versions, "go1.22.4")
+
+	env, err := ToolchainEnv(goRoot, []string{"HOME=" + home, "GOROOT=/usr/local/go", "PATH=/usr/bin"})
+	if err != nil {
+		t.Fatalf("ToolchainEnv: %v", err)
+	}
+	want := []string{
+		"HOME=" + home,
+		"GOROOT=" + goRoot,
+		"PATH=" + filepath.Join(goRoot, "bin") + string(os.PathListSeparator) + "/usr/bin",
+	}
+	if !slices.Equal(env, want) {
+		t.Errorf("env = %v, want %v", env, want)
+	}
+}
+
+func TestGoRoot(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	if _, err := GoRoot("go1.22.4"); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("GoRoot: err = %v, want ErrNotInstalled", err)
+	}
+	if _, err := GoRoot(""); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("GoRoot (no current): err = %v, want ErrNotInstalled", err)
+	}
+	want := filepath.Join(home, gmDir, versions, "go1.22.4")
+	if err := os.MkdirAll(want, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if got, err := GoRoot("go1.22.4"); err != nil || got != want {
+		t.Errorf("GoRoot = %q, %v, want %q", got, err, want)
+	}
+}
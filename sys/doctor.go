@@ -0,0 +1,271 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// Status is a result of diagnostic check.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Diagnosis is a result of checking one aspect of Go setup.
+type Diagnosis struct {
+	Check   string
+	Status  Status
+	Message string
+	// Fix suggests how to resolve problem, empty if there is none
+	Fix string
+	// Apply resolves problem, nil if fix is not safe to apply automatically
+	Apply func() error
+}
+
+// ProfileLine is a line of shell profile.
+type ProfileLine struct {
+	File string
+	Line int
+	Text string
+}
+
+func (l ProfileLine) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// goBinary returns name of go command executable.
+func goBinary() string {
+	if runtime.GOOS == "windows" {
+		return "go.exe"
+	}
+	return "go"
+}
+
+// CheckPath checks that go command found first in path list belongs to toolchain managed by gm.
+func CheckPath(path string) Diagnosis {
+	d := Diagnosis{Check: "PATH"}
+	versionsPath, err := PathForVersion("")
+	if err != nil {
+		d.Status, d.Message = StatusFail, err.Error()
+		return d
+	}
+	prefix := filepath.Clean(versionsPath) + string(filepath.Separator)
+
+	var found []string
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		if fi, err := os.Stat(filepath.Join(dir, goBinary())); err == nil && !fi.IsDir() {
+			found = append(found, dir)
+		}
+	}
+	switch {
+	case len(found) == 0:
+		d.Status, d.Message = StatusFail, "go command is not found in PATH"
+		d.Fix = "run 'gm init' to set up your shell profile and start a new shell"
+	case !strings.HasPrefix(filepath.Clean(found[0])+string(filepath.Separator), prefix):
+		d.Status, d.Message = StatusFail, fmt.Sprintf("go command of another installation comes first in PATH: %s", found[0])
+		d.Fix = fmt.Sprintf("remove %s from PATH or run %s after it is added", found[0], EnvCommand())
+	default:
+		d.Status, d.Message = StatusPass, fmt.Sprintf("go command is managed by gm: %s", found[0])
+		if len(found) > 1 {
+			d.Message += fmt.Sprintf(", shadowed installations: %s", strings.Join(found[1:], ", "))
+		}
+	}
+	return d
+}
+
+// CheckGoRoot checks that GOROOT, if set, points to toolchain managed by gm.
+// Lines of profiles assigning GOROOT are suggested for removal.
+func CheckGoRoot(goRoot string, profiles []string) Diagnosis {
+	d := Diagnosis{Check: "GOROOT"}
+	versionsPath, err := PathForVersion("")
+	if err != nil {
+		d.Status, d.Message = StatusFail, err.Error()
+		return d
+	}
+	switch {
+	case goRoot == "":
+		d.Status, d.Message = StatusPass, "GOROOT is not set, go command uses its own location"
+		return d
+	case strings.HasPrefix(filepath.Clean(goRoot)+string(filepath.Separator), filepath.Clean(versionsPath)+string(filepath.Separator)):
+		d.Status, d.Message = StatusPass, fmt.Sprintf("GOROOT points to toolchain managed by gm: %s", goRoot)
+		return d
+	}
+
+	d.Status = StatusFail
+	d.Message = fmt.Sprintf("GOROOT points outside of gm: %s", goRoot)
+	if _, err := os.Stat(goRoot); err != nil {
+		d.Message += " (directory does not exist)"
+	}
+	var lines []string
+	for _, l := range FindInProfiles(profiles, "GOROOT") {
+		if !strings.Contains(l.Text, "gm env") {
+			lines = append(lines, l.String())
+		}
+	}
+	if len(lines) > 0 {
+		d.Fix = fmt.Sprintf("remove GOROOT assignment from %s and start a new shell", strings.Join(lines, ", "))
+	} else {
+		d.Fix = "find where GOROOT is exported and remove it, or unset GOROOT"
+	}
+	return d
+}
+
+// CheckCurrent checks that current version points to installed toolchain.
+// Dangling link is fixed by setting the newest installed release as current.
+func CheckCurrent() Diagnosis {
+	d := Diagnosis{Check: "current version"}
+	currentPath, err := PathForVersion(current)
+	if err != nil {
+		d.Status, d.Message = StatusFail, err.Error()
+		return d
+	}
+	if _, err := os.Lstat(currentPath); os.IsNotExist(err) {
+		d.Status, d.Message = StatusWarn, "no version is set as current"
+		d.Fix = "set current version with 'gm use <version>'"
+		return d
+	}
+	if _, err := os.Stat(currentPath); err == nil {
+		tc, err := GetCurrentVersion()
+		if err != nil || tc == nil {
+			d.Status, d.Message = StatusFail, fmt.Sprintf("can not determine current version: %v", err)
+			return d
+		}
+		d.Status, d.Message = StatusPass, fmt.Sprintf("current version is %s", tc.Version)
+		return d
+	}
+
+	target, _ := os.Readlink(currentPath)
+	d.Status, d.Message = StatusFail, fmt.Sprintf("current version points to missing directory %s", target)
+	latest, err := LatestInstalled()
+	if err != nil {
+		d.Fix = "remove dangling link of current version"
+		d.Apply = func() error {
+			return os.Remove(currentPath)
+		}
+		return d
+	}
+	d.Fix = fmt.Sprintf("set the newest installed release %s as current", latest.Version)
+	d.Apply = func() error {
+		return SetAsCurrent("go" + latest.Version)
+	}
+	return d
+}
+
+// CheckProfile checks that one of shell profiles sets up environment with
+// gm block or 'gm env'.
+func CheckProfile(profiles []string) Diagnosis {
+	d := Diagnosis{Check: "shell profile"}
+	for _, file := range profiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		if _, at := cutProfileBlock(string(data)); at >= 0 {
+			d.Status, d.Message = StatusPass, fmt.Sprintf("environment is set up by gm block in %s", file)
+			return d
+		}
+	}
+	if lines := FindInProfiles(profiles, "gm env"); len(lines) > 0 {
+		d.Status, d.Message = StatusPass, fmt.Sprintf("environment is set up in %s", lines[0])
+		return d
+	}
+	d.Status, d.Message = StatusWarn, "no shell profile runs 'gm env'"
+	d.Fix = "run 'gm init' to set up your shell profile"
+	return d
+}
+
+// CheckToolchainSwitch checks that go command of toolchain version does not switch
+// to toolchains downloaded outside of gm. GOTOOLCHAIN other than "local" is fine
+// when GOPROXY points to gm toolchain proxy at proxyAddr. Toolchains older than
+// Go 1.21 do not switch and report no value. Fix is only suggested: writing go env
+// file affects all toolchains and disables switching set up with 'gm env --proxy'.
+func CheckToolchainSwitch(value string, fromEnv bool, goProxy, proxyAddr, version string) Diagnosis {
+	d := Diagnosis{Check: "GOTOOLCHAIN"}
+	switch {
+	case value == "" || gover.IsValid(version) && gover.Compare(version, "go1.21") < 0:
+		d.Status, d.Message = StatusPass, fmt.Sprintf("go command of Go %s does not switch toolchains", strings.TrimPrefix(version, "go"))
+		return d
+	case value == "local":
+		d.Status, d.Message = StatusPass, "go command uses selected toolchain only"
+		return d
+	case strings.Contains(goProxy, proxyAddr):
+		d.Status, d.Message = StatusPass, fmt.Sprintf("GOTOOLCHAIN=%s switches to toolchains served by gm proxy", value)
+		return d
+	}
+	d.Status = StatusWarn
+	d.Message = fmt.Sprintf("GOTOOLCHAIN=%s, go command silently switches to toolchains required by go.mod, downloading them outside of gm", value)
+	if fromEnv {
+		d.Fix = "remove GOTOOLCHAIN from environment or set it to local, use 'gm proxy serve' to switch to gm toolchains"
+		return d
+	}
+	d.Fix = "use 'gm proxy serve' with 'gm env --proxy' to switch to gm toolchains, or run 'go env -w GOTOOLCHAIN=local' to disable switching"
+	return d
+}
+
+// CheckBackup checks for backup of gm binary left by upgrade.
+func CheckBackup(executable string) Diagnosis {
+	d := Diagnosis{Check: "gm binary"}
+	backup := executable + ".bak"
+	if _, err := os.Stat(backup); err != nil {
+		d.Status, d.Message = StatusPass, "no leftovers of upgrade"
+		return d
+	}
+	d.Status, d.Message = StatusWarn, fmt.Sprintf("backup of previous version is left by upgrade: %s", backup)
+	d.Fix = "remove the backup"
+	d.Apply = func() error {
+		return os.Remove(backup)
+	}
+	return d
+}
+
+// GoEnv returns values of go environment variables reported by go command of goRoot.
+func GoEnv(goRoot string, names ...string) (map[string]string, error) {
+	args := append([]string{"env", "-json"}, names...)
+	out, err := exec.Command(filepath.Join(goRoot, "bin", goBinary()), args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("run go env: %w", err)
+	}
+	values := make(map[string]string, len(names))
+	if err := json.Unmarshal(out, &values); err != nil {
+		return nil, fmt.Errorf("decode go env: %w", err)
+	}
+	return values, nil
+}
+
+// FindInProfiles returns lines of profiles containing text. Missing profiles are skipped.
+func FindInProfiles(profiles []string, text string) []ProfileLine {
+	var found []ProfileLine
+	for _, file := range profiles {
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		sc := bufio.NewScanner(f)
+		for n := 1; sc.Scan(); n++ {
+			line := strings.TrimSpace(sc.Text())
+			if strings.HasPrefix(line, "#") || !strings.Contains(line, text) {
+				continue
+			}
+			found = append(found, ProfileLine{File: file, Line: n, Text: line})
+		}
+		f.Close()
+	}
+	return found
+}
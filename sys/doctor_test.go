@@ -0,0 +1,201 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeGo creates dummy go command in dir.
+func writeGo(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, goBinary()), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	managed := filepath.Join(home, gmDir, versions, current, "bin")
+	other := filepath.Join(t.TempDir(), "go", "bin")
+	writeGo(t, managed)
+	writeGo(t, other)
+	empty := t.TempDir()
+
+	tests := []struct {
+		name string
+		path []string
+		want Status
+	}{
+		{"managed first", []string{empty, managed, other}, StatusPass},
+		{"other first", []string{other, managed}, StatusFail},
+		{"not found", []string{empty}, StatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := CheckPath(strings.Join(tt.path, string(os.PathListSeparator)))
+			if d.Status != tt.want {
+				t.Errorf("status = %s, want %s (%s)", d.Status, tt.want, d.Message)
+			}
+			if tt.want == StatusFail && d.Fix == "" {
+				t.Error("expected fix suggestion")
+			}
+		})
+	}
+}
+
+func TestCheckGoRoot(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	profile := filepath.Join(home, ".bashrc")
+	content := "# export GOROOT=/opt/old\nexport GOROOT=/usr/local/go\neval \"$(gm env)\"\n"
+	if err := os.WriteFile(profile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if d := CheckGoRoot("", []string{profile}); d.Status != StatusPass {
+		t.Errorf("unset GOROOT: status = %s, want pass", d.Status)
+	}
+	if d := CheckGoRoot(filepath.Join(home, gmDir, versions, current), []string{profile}); d.Status != StatusPass {
+		t.Errorf("managed GOROOT: status = %s, want pass", d.Status)
+	}
+	d := CheckGoRoot("/usr/local/go", []string{profile, filepath.Join(home, ".profile")})
+	if d.Status != StatusFail {
+		t.Fatalf("foreign GOROOT: status = %s, want fail", d.Status)
+	}
+	if want := profile + ":2"; !strings.Contains(d.Fix, want) || strings.Contains(d.Fix, profile+":1") {
+		t.Errorf("fix = %q, want it to point to %s only", d.Fix, want)
+	}
+	if d.Apply != nil {
+		t.Error("editing profiles must not be applied automatically")
+	}
+}
+
+func TestCheckCurrent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("dangling junctions are not created on Windows")
+	}
+	home := t.TempDir()
+	setHome(t, home)
+	versionsDir := filepath.Join(home, gmDir, versions)
+	for _, v := range []string{"go1.21.5", "go1.22.1"} {
+		if err := os.MkdirAll(filepath.Join(versionsDir, v, "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if d := CheckCurrent(); d.Status != StatusWarn {
+		t.Errorf("no current: status = %s, want warn", d.Status)
+	}
+
+	currentPath := filepath.Join(versionsDir, current)
+	if err := os.Symlink(filepath.Join(versionsDir, "go1.23.0"), currentPath); err != nil {
+		t.Fatal(err)
+	}
+	d := CheckCurrent()
+	if d.Status != StatusFail || d.Apply == nil {
+		t.Fatalf("dangling current: status = %s, apply = %v, want fail with fix", d.Status, d.Apply != nil)
+	}
+	if err := d.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	target, err := os.Readlink(currentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(target) != "go1.22.1" {
+		t.Errorf("current = %s, want go1.22.1", target)
+	}
+	if d := CheckCurrent(); d.Status != StatusPass {
+		t.Errorf("after fix: status = %s, want pass (%s)", d.Status, d.Message)
+	}
+}
+
+func TestCheckProfile(t *testing.T) {
+	dir := t.TempDir()
+	profile := filepath.Join(dir, ".zshrc")
+	missing := filepath.Join(dir, ".zprofile")
+
+	if d := CheckProfile([]string{profile, missing}); d.Status != StatusWarn || !strings.Contains(d.Fix, "gm init") {
+		t.Errorf("no profile: status = %s, fix = %q, want warn suggesting gm init", d.Status, d.Fix)
+	}
+	if err := os.WriteFile(profile, []byte("# eval \"$(gm env)\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if d := CheckProfile([]string{profile}); d.Status != StatusWarn {
+		t.Errorf("commented out: status = %s, want warn", d.Status)
+	}
+	if err := os.WriteFile(profile, []byte("alias ll='ls -l'\neval \"$(gm env --hook)\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if d := CheckProfile([]string{missing, profile}); d.Status != StatusPass {
+		t.Errorf("set up: status = %s, want pass", d.Status)
+	}
+	block := ProfileBegin + "\n$env.GOROOT = '/home/user/.gm/versions/current'\n" + ProfileEnd + "\n"
+	if err := os.WriteFile(profile, []byte(block), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if d := CheckProfile([]string{profile}); d.Status != StatusPass {
+		t.Errorf("gm block: status = %s, want pass", d.Status)
+	}
+}
+
+func TestCheckToolchainSwitch(t *testing.T) {
+	const addr = "127.0.0.1:7871"
+	tests := []struct {
+		name    string
+		value   string
+		fromEnv bool
+		goProxy string
+		version string
+		want    Status
+	}{
+		{"local", "local", false, "https://proxy.golang.org,direct", "1.22.4", StatusPass},
+		{"gm proxy", "auto", false, "http://" + addr + ",direct", "1.22.4", StatusPass},
+		{"auto in go env", "auto", false, "https://proxy.golang.org,direct", "1.22.4", StatusWarn},
+		{"auto in environment", "auto", true, "https://proxy.golang.org,direct", "1.22.4", StatusWarn},
+		{"not reported", "", false, "https://proxy.golang.org,direct", "1.20.14", StatusPass},
+		{"before go1.21", "auto", true, "https://proxy.golang.org,direct", "1.20.14", StatusPass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := CheckToolchainSwitch(tt.value, tt.fromEnv, tt.goProxy, addr, tt.version)
+			if d.Status != tt.want {
+				t.Errorf("status = %s, want %s", d.Status, tt.want)
+			}
+			// go env file is shared by all toolchains, fix is never applied automatically
+			if d.Apply != nil {
+				t.Error("apply is set, want suggestion only")
+			}
+		})
+	}
+}
+
+func TestCheckBackup(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "gm")
+	if d := CheckBackup(exe); d.Status != StatusPass {
+		t.Errorf("no backup: status = %s, want pass", d.Status)
+	}
+	if err := os.WriteFile(exe+".bak", []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	d := CheckBackup(exe)
+	if d.Status != StatusWarn || d.Apply == nil {
+		t.Fatalf("backup: status = %s, want warn with fix", d.Status)
+	}
+	if err := d.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if _, err := os.Stat(exe + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("backup is not removed: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Link registers toolchain installed outside of gm at target under the given version name.
+// Registered toolchain is never modified by gm, only the link to it is managed.
+func Link(version, target string) error {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return fmt.Errorf("resolve path %q: %w", target, err)
+	}
+	versionPath, err := PathForName(version)
+	if err != nil {
+		return fmt.Errorf("get path for version: %w", err)
+	}
+
+	if _, err := os.Lstat(versionPath); err == nil {
+		return ErrInstalled
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("check installed version: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(versionPath), 0755); err != nil {
+		return fmt.Errorf("create versions directory: %w", err)
+	}
+	return createSymlink(target, versionPath)
+}
+
+// Uninstall removes installed version. Files of external toolchains are kept,
+// such toolchains are only unregistered. It returns removed toolchain.
+func Uninstall(version string) (*Toolchain, error) {
+	versionPath, err := PathForName(version)
+	if err != nil {
+		return nil, fmt.Errorf("get path for version: %w", err)
+	}
+
+	fi, err := os.Lstat(versionPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotInstalled
+		}
+		return nil, fmt.Errorf("check installed version: %w", err)
+	}
+
+	current, err := GetCurrentVersion()
+	if err != nil {
+		return nil, fmt.Errorf("get current version: %w", err)
+	}
+	if current != nil && filepath.Base(current.Path) == filepath.Base(versionPath) {
+		return nil, ErrIsCurrent
+	}
+
+	tc := Toolchain{Path: versionPath, Version: strings.TrimPrefix(filepath.Base(versionPath), "go")}
+	if isLink(fi.Mode()) {
+		if tc.Path, err = os.Readlink(versionPath); err != nil {
+			return nil, fmt.Errorf("read link of external version: %w", err)
+		}
+		tc.External = true
+		if err := os.Remove(versionPath); err != nil {
+			return nil, fmt.Errorf("unregister external version: %w", err)
+		}
+		return &tc, nil
+	}
+
+	if err := os.RemoveAll(versionPath); err != nil {
+		return nil, fmt.Errorf("remove installed version: %w", err)
+	}
+	return &tc, nil
+}
+
+// isLink reports whether mode describes a symbolic link or a directory junction
+// (reported as irregular file on Windows).
+func isLink(mode os.FileMode) bool {
+	return mode&(os.ModeSymlink|os.ModeIrregular) != 0
+}
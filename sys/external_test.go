@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLinkAndUninstallExternal(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	external := filepath.Join(t.TempDir(), "go")
+	if err := os.MkdirAll(filepath.Join(external, "bin"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := Link("go1.22.4", external); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	if err := Link("go1.22.4", external); !errors.Is(err, ErrInstalled) {
+		t.Errorf("second Link: err = %v, want ErrInstalled", err)
+	}
+
+	installed, err := ListInstalledVersions()
+	if err != nil {
+		t.Fatalf("ListInstalledVersions: %v", err)
+	}
+	if len(installed) != 1 {
+		t.Fatalf("got %v, want single external version", installed)
+	}
+	if tc := installed[0]; tc.Version != "1.22.4" || !tc.External || tc.Path != external {
+		t.Errorf("got %+v, want external 1.22.4 at %s", tc, external)
+	}
+
+	if err := SetAsCurrent("go1.22.4"); err != nil {
+		t.Fatalf("SetAsCurrent: %v", err)
+	}
+	if _, err := Uninstall("go1.22.4"); !errors.Is(err, ErrIsCurrent) {
+		t.Errorf("Uninstall current: err = %v, want ErrIsCurrent", err)
+	}
+
+	versionsDir := filepath.Join(home, gmDir, versions)
+	if err := os.Remove(filepath.Join(versionsDir, current)); err != nil {
+		t.Fatalf("remove current: %v", err)
+	}
+	tc, err := Uninstall("go1.22.4")
+	if err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	if !tc.External {
+		t.Errorf("Uninstall returned %+v, want external toolchain", tc)
+	}
+	if _, err := os.Stat(filepath.Join(external, "bin")); err != nil {
+		t.Errorf("files of external toolchain must be kept: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(versionsDir, "go1.22.4")); !os.IsNotExist(err) {
+		t.Errorf("link must be removed, lstat err = %v", err)
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	if _, err := Uninstall("go1.21.0"); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("err = %v, want ErrNotInstalled", err)
+	}
+
+	versionPath := filepath.Join(home, gmDir, versions, "go1.21.0")
+	if err := os.MkdirAll(filepath.Join(versionPath, "bin"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	tc, err := Uninstall("go1.21.0")
+	if err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	if tc.External || tc.Version != "1.21.0" {
+		t.Errorf("got %+v, want managed 1.21.0", tc)
+	}
+	if _, err := os.Stat(versionPath); !os.IsNotExist(err) {
+		t.Errorf("version directory must be removed, stat err = %v", err)
+	}
+}
+
+func TestUninstall_InvalidName(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	keep := filepath.Join(home, "keep.txt")
+	if err := os.WriteFile(keep, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(home, gmDir, versions, "go1.22.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"go/../../..", "go/../..", "..", "go1.22.0/bin", `go..\x`, "current", ""} {
+		if _, err := Uninstall(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Uninstall(%q): err = %v, want ErrInvalidName", name, err)
+		}
+		if err := Link(name, t.TempDir()); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Link(%q): err = %v, want ErrInvalidName", name, err)
+		}
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Errorf("file in home is removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, gmDir, versions, "go1.22.0")); err != nil {
+		t.Errorf("installed version is removed: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// VersionStable selects the newest stable release in version lists of build matrix.
+const VersionStable = "stable"
+
+var ErrNoRelease = errors.New("no release found")
+
+// MatrixVersion is a toolchain selected for build matrix.
+type MatrixVersion struct {
+	// Spec is version as requested, e.g. "1.21", "1.22.4" or "stable"
+	Spec string
+	// Version with "go" prefix
+	Version string
+	// Installed toolchain, nil if the version is missing
+	Installed *Toolchain
+}
+
+// ResolveMatrix selects toolchains for requested versions. Minor lines like "1.21"
+// select the newest installed release of the line, or the newest release from catalog
+// when none is installed. "stable" selects the newest stable release from catalog,
+// or the newest installed release when catalog is nil. Other versions are selected
+// exactly. Versions selected by several specs are listed once.
+func ResolveMatrix(specs []string, installed []Toolchain, catalog *toolchain.Catalog) ([]MatrixVersion, error) {
+	releases := slices.DeleteFunc(slices.Clone(installed), func(tc Toolchain) bool {
+		v, err := gover.Parse(tc.Version)
+		return err != nil || !v.IsRelease() || tc.VersionMismatch()
+	})
+	gover.SortDesc(releases, func(tc Toolchain) string { return tc.Version })
+
+	var selected []MatrixVersion
+	for _, spec := range specs {
+		mv, err := resolveMatrixVersion(spec, installed, releases, catalog)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(selected, func(s MatrixVersion) bool { return s.Version == mv.Version }) {
+			selected = append(selected, mv)
+		}
+	}
+	return selected, nil
+}
+
+func resolveMatrixVersion(spec string, installed, releases []Toolchain, catalog *toolchain.Catalog) (MatrixVersion, error) {
+	mv := MatrixVersion{Spec: spec}
+	if spec == VersionStable {
+		switch {
+		case catalog != nil:
+			mv.Version = catalog.Latest()
+		case len(releases) > 0:
+			mv.Version = "go" + releases[0].Version
+		}
+		if mv.Version == "" {
+			return mv, fmt.Errorf("resolve %s: %w", spec, ErrNoRelease)
+		}
+		mv.Installed = findToolchain(installed, mv.Version)
+		return mv, nil
+	}
+
+	version := "go" + strings.TrimPrefix(spec, "go")
+	if tc := findToolchain(installed, version); tc != nil {
+		// also selects toolchains built from source
+		mv.Version, mv.Installed = version, tc
+		return mv, nil
+	}
+	v, err := gover.Parse(version)
+	if err != nil {
+		return mv, fmt.Errorf("resolve %s: %w", spec, err)
+	}
+	if v.Patch >= 0 || v.Kind != "" {
+		mv.Version = v.String()
+		return mv, nil
+	}
+
+	// minor line
+	for _, tc := range releases {
+		if tv, _ := gover.Parse(tc.Version); tv.Lang() == v.Lang() {
+			mv.Version = "go" + tc.Version
+			mv.Installed = &tc
+			return mv, nil
+		}
+	}
+	if catalog != nil {
+		mv.Version = catalog.Status(v.String()).Latest
+	}
+	if mv.Version == "" {
+		return mv, fmt.Errorf("resolve %s: %w", spec, ErrNoRelease)
+	}
+	return mv, nil
+}
+
+func findToolchain(installed []Toolchain, version string) *Toolchain {
+	i := slices.IndexFunc(installed, func(tc Toolchain) bool { return "go"+tc.Version == version })
+	if i < 0 {
+		return nil
+	}
+	return &installed[i]
+}
+
+// IsolatedEnv returns environ with variables set like PrepareGoEnvs does, but pointing
+// directly at toolchain in goRoot instead of current version. GOTOOLCHAIN is set to
+// local, so go command does not switch to toolchain required by go.mod.
+func IsolatedEnv(goRoot string, environ []string) ([]string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("get home dir of user: %w", err)
+	}
+	goPath := filepath.Join(homedir, gmDir, workspace)
+
+	env := make([]string, 0, len(environ)+3)
+	for _, kv := range environ {
+		name, _, _ := strings.Cut(kv, "=")
+		if envNameIs(name, "GOPATH") || envNameIs(name, "GOBIN") || envNameIs(name, "GOTOOLCHAIN") {
+			continue
+		}
+		env = append(env, kv)
+	}
+	env = append(env, "GOPATH="+goPath, "GOBIN="+filepath.Join(goPath, "bin"), "GOTOOLCHAIN=local")
+	return ToolchainEnv(goRoot, env)
+}
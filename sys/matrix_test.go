@@ -0,0 +1,109 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/x-dvr/gm/toolchain"
+)
+
+func TestResolveMatrix(t *testing.T) {
+	installed := []Toolchain{
+		{Version: "1.21.8"},
+		{Version: "1.22.1"},
+		{Version: "1.21.13"},
+		{Version: "tip-0123456"},
+	}
+	catalog := &toolchain.Catalog{Releases: []toolchain.Release{
+		{Version: "go1.23rc1"},
+		{Version: "go1.22.4", Stable: true},
+		{Version: "go1.21.13", Stable: true},
+		{Version: "go1.20.14", Stable: true},
+	}}
+	type result struct {
+		spec, version, installed string
+	}
+	tests := []struct {
+		name    string
+		specs   []string
+		catalog *toolchain.Catalog
+		want    []result
+	}{
+		{
+			name:    "catalog",
+			specs:   []string{"1.21", "1.22", "stable", "1.20", "go1.19.2", "tip-0123456"},
+			catalog: catalog,
+			want: []result{
+				{"1.21", "go1.21.13", "1.21.13"},
+				{"1.22", "go1.22.1", "1.22.1"},
+				{"stable", "go1.22.4", ""},
+				{"1.20", "go1.20.14", ""},
+				{"go1.19.2", "go1.19.2", ""},
+				{"tip-0123456", "gotip-0123456", "tip-0123456"},
+			},
+		},
+		{
+			name:  "offline",
+			specs: []string{"stable", "1.22.1", "1.21.8"},
+			want: []result{
+				{"stable", "go1.22.1", "1.22.1"},
+				{"1.21.8", "go1.21.8", "1.21.8"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := ResolveMatrix(tt.specs, installed, tt.catalog)
+			if err != nil {
+				t.Fatalf("ResolveMatrix: %v", err)
+			}
+			var got []result
+			for _, mv := range selected {
+				r := result{mv.Spec, mv.Version, ""}
+				if mv.Installed != nil {
+					r.installed = mv.Installed.Version
+				}
+				got = append(got, r)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("selected = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolveMatrix([]string{"1.20"}, installed, nil); !errors.Is(err, ErrNoRelease) {
+		t.Errorf("missing line offline: err = %v, want ErrNoRelease", err)
+	}
+	if _, err := ResolveMatrix([]string{"newest"}, installed, catalog); err == nil {
+		t.Error("invalid version: err = nil")
+	}
+}
+
+func TestIsolatedEnv(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	goRoot := filepath.Join(home, gmDir, versions, "go1.22.4")
+	goPath := filepath.Join(home, gmDir, workspace)
+
+	env, err := IsolatedEnv(goRoot, []string{"GOPATH=/go", "GOTOOLCHAIN=auto", "GOFLAGS=-v", "PATH=/usr/bin"})
+	if err != nil {
+		t.Fatalf("IsolatedEnv: %v", err)
+	}
+	want := []string{
+		"GOFLAGS=-v",
+		"GOPATH=" + goPath,
+		"GOBIN=" + filepath.Join(goPath, "bin"),
+		"GOTOOLCHAIN=local",
+		"GOROOT=" + goRoot,
+		"PATH=" + filepath.Join(goRoot, "bin") + string(os.PathListSeparator) + "/usr/bin",
+	}
+	if !slices.Equal(env, want) {
+		t.Errorf("env = %v, want %v", env, want)
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Supported shells of profile integration.
+const (
+	ShellBash = "bash"
+	ShellZsh  = "zsh"
+	ShellFish = "fish"
+	ShellNu   = "nu"
+)
+
+// Markers of profile block added by gm init.
+const (
+	ProfileBegin = "# >>> gm (Go version manager) >>>"
+	ProfileEnd   = "# <<< gm (Go version manager) <<<"
+	// legacyMarker starts lines added by install script of older versions
+	legacyMarker = "# GM (Go version manager)"
+)
+
+// Shells lists supported shells.
+var Shells = []string{ShellBash, ShellZsh, ShellFish, ShellNu}
+
+// ParseShell returns supported shell by its name or path, e.g. /bin/zsh or nushell.
+func ParseShell(name string) (string, error) {
+	shell := strings.TrimSuffix(filepath.Base(name), ".exe")
+	if shell == "nushell" {
+		shell = ShellNu
+	}
+	for _, s := range Shells {
+		if s == shell {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q (supported: %s)", ErrUnsupportedShell, name, strings.Join(Shells, ", "))
+}
+
+// RootPath returns path of the directory where gm keeps toolchains, workspace and caches.
+func RootPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir), nil
+}
+
+// ProfileFiles returns startup files of shell gm block may be added to,
+// the preferred file goes first.
+func ProfileFiles(shell string) ([]string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("get home dir of user: %w", err)
+	}
+	switch shell {
+	case ShellBash:
+		return []string{filepath.Join(homedir, ".bashrc"), filepath.Join(homedir, ".bash_profile")}, nil
+	case ShellZsh:
+		dir := os.Getenv("ZDOTDIR")
+		if dir == "" {
+			dir = homedir
+		}
+		// .zshenv is read by all shells, including non-interactive ones
+		return []string{filepath.Join(dir, ".zshenv")}, nil
+	case ShellFish:
+		dir := os.Getenv("XDG_CONFIG_HOME")
+		if dir == "" {
+			dir = filepath.Join(homedir, ".config")
+		}
+		return []string{filepath.Join(dir, "fish", "config.fish")}, nil
+	case ShellNu:
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("get config dir of user: %w", err)
+		}
+		return []string{filepath.Join(dir, "nushell", "env.nu")}, nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedShell, shell)
+	}
+}
+
+// ProfileFile returns startup file of shell to add gm block to.
+// Bash profile is the first existing of .bashrc and .bash_profile.
+func ProfileFile(shell string) (string, error) {
+	files, err := ProfileFiles(shell)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if _, err := os.Stat(f); err == nil {
+			return f, nil
+		}
+	}
+	return files[0], nil
+}
+
+// ProfileBlock returns gm block for shell, adding binDir with gm binary to PATH
+// and setting up environment of current version.
+func ProfileBlock(shell, binDir string) (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	// paths under home are written relative to it, so profile survives home move
+	home := func(path, prefix string) string {
+		rel, err := filepath.Rel(homedir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return path
+		}
+		return prefix + "/" + filepath.ToSlash(rel)
+	}
+
+	var lines []string
+	switch shell {
+	case ShellBash, ShellZsh:
+		lines = []string{
+			fmt.Sprintf(`export PATH="%s:$PATH"`, home(binDir, "$HOME")),
+			`eval "$(gm env)"`,
+		}
+	case ShellFish:
+		lines = []string{
+			fmt.Sprintf("set -gx PATH %s $PATH", home(binDir, "$HOME")),
+			"gm env | source",
+		}
+	case ShellNu:
+		// nushell can not evaluate output of gm env, variables are set directly
+		nuPath := func(path string) string {
+			p := home(path, "~")
+			if p == path {
+				return fmt.Sprintf("'%s'", p)
+			}
+			return fmt.Sprintf("('%s' | path expand)", p)
+		}
+		goPath := filepath.Join(homedir, gmDir, workspace)
+		goRoot := filepath.Join(homedir, gmDir, versions, current)
+		lines = []string{
+			"$env.GOPATH = " + nuPath(goPath),
+			"$env.GOBIN = " + nuPath(filepath.Join(goPath, "bin")),
+			"$env.GOROOT = " + nuPath(goRoot),
+			fmt.Sprintf("$env.PATH = ($env.PATH | split row (char esep) | prepend [%s %s %s])",
+				nuPath(binDir), nuPath(filepath.Join(goRoot, "bin")), nuPath(filepath.Join(goPath, "bin"))),
+		}
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnsupportedShell, shell)
+	}
+	return strings.Join(append(append([]string{ProfileBegin}, lines...), ProfileEnd), "\n") + "\n", nil
+}
+
+// AddProfileBlock adds block to profile file, replacing gm block added before,
+// including lines of older install script. It reports whether file is changed.
+func AddProfileBlock(file, block string) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("read profile: %w", err)
+	}
+	content := string(data)
+	if strings.Contains(content, block) && !strings.Contains(content, legacyMarker) {
+		return false, nil
+	}
+
+	updated, at := cutProfileBlock(content)
+	if at < 0 {
+		at = len(updated)
+	}
+	before, after := updated[:at], updated[at:]
+	if before != "" && !strings.HasSuffix(before, "\n") {
+		before += "\n"
+	}
+	if before != "" {
+		before += "\n"
+	}
+	if err := writeProfile(file, before+block+after); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveProfileBlock removes gm block from profile file, including lines
+// of older install script. It reports whether file is changed.
+func RemoveProfileBlock(file string) (bool, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read profile: %w", err)
+	}
+	updated, at := cutProfileBlock(string(data))
+	if at < 0 {
+		return false, nil
+	}
+	if err := writeProfile(file, updated); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ProfilesWithBlock returns profile files of supported shells containing gm block.
+func ProfilesWithBlock() ([]string, error) {
+	var found []string
+	for _, shell := range Shells {
+		files, err := ProfileFiles(shell)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			if _, at := cutProfileBlock(string(data)); at >= 0 && !slices.Contains(found, file) {
+				found = append(found, file)
+			}
+		}
+	}
+	return found, nil
+}
+
+// cutProfileBlock removes gm blocks and the blank lines separating them from
+// preceding content. It returns updated content and offset of the first removed
+// block, -1 if there is none.
+func cutProfileBlock(content string) (string, int) {
+	lines := strings.SplitAfter(content, "\n")
+	kept := make([]string, 0, len(lines))
+	at := -1
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		end := -1
+		switch line {
+		case ProfileBegin:
+			for j := i + 1; j < len(lines); j++ {
+				if strings.TrimSpace(lines[j]) == ProfileEnd {
+					end = j
+					break
+				}
+			}
+		case legacyMarker:
+			end = i
+			for j := i + 1; j < len(lines) && j <= i+2; j++ {
+				if l := lines[j]; !strings.Contains(l, ".gm/bin") && !strings.Contains(l, "gm env") {
+					break
+				}
+				end = j
+			}
+		}
+		if end < 0 {
+			kept = append(kept, lines[i])
+			continue
+		}
+		if n := len(kept); n > 0 && strings.TrimSpace(kept[n-1]) == "" {
+			kept = kept[:n-1]
+		}
+		if at < 0 {
+			at = len(strings.Join(kept, ""))
+		}
+		i = end
+	}
+	return strings.Join(kept, ""), at
+}
+
+// writeProfile replaces contents of profile file, keeping its permissions.
+// Symlinked profile, e.g. managed by dotfiles tool, is written through the link.
+func writeProfile(file, content string) error {
+	if target, err := filepath.EvalSymlinks(file); err == nil {
+		file = target
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("resolve profile: %w", err)
+	}
+	mode := fs.FileMode(0644)
+	if fi, err := os.Stat(file); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("create profile directory: %w", err)
+	}
+	tmp := file + ".gm-tmp"
+	if err := os.WriteFile(tmp, []byte(content), mode); err != nil {
+		return fmt.Errorf("write profile: %w", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write profile: %w", err)
+	}
+	return nil
+}
+
+// RemoveRoot deletes directory of gm with all toolchains, workspace and caches.
+// Read-only directories, e.g. of module cache, are made writable first.
+// Toolchains registered with Link are kept, only links to them are removed.
+func RemoveRoot() error {
+	root, err := RootPath()
+	if err != nil {
+		return err
+	}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if fi, err := d.Info(); err == nil && fi.Mode().Perm()&0200 == 0 {
+				return os.Chmod(path, fi.Mode().Perm()|0200)
+			}
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("make gm directory writable: %w", err)
+	}
+	if err := os.RemoveAll(root); err != nil {
+		return fmt.Errorf("remove gm directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseShell(t *testing.T) {
+	tests := map[string]string{
+		"/bin/bash":          ShellBash,
+		"/usr/local/bin/zsh": ShellZsh,
+		"fish":               ShellFish,
+		"nushell":            ShellNu,
+		"nu.exe":             ShellNu,
+	}
+	for name, want := range tests {
+		got, err := ParseShell(name)
+		if err != nil || got != want {
+			t.Errorf("ParseShell(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseShell("/bin/tcsh"); !errors.Is(err, ErrUnsupportedShell) {
+		t.Errorf("ParseShell(tcsh) error = %v, want ErrUnsupportedShell", err)
+	}
+}
+
+func TestProfileFile(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	t.Setenv("ZDOTDIR", "")
+
+	got, err := ProfileFile(ShellBash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(home, ".bashrc"); got != want {
+		t.Errorf("bash without profiles = %s, want %s", got, want)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".bash_profile"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = ProfileFile(ShellBash)
+	if want := filepath.Join(home, ".bash_profile"); got != want {
+		t.Errorf("bash with .bash_profile = %s, want %s", got, want)
+	}
+	got, _ = ProfileFile(ShellZsh)
+	if want := filepath.Join(home, ".zshenv"); got != want {
+		t.Errorf("zsh = %s, want %s", got, want)
+	}
+}
+
+func TestProfileBlock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("paths are unix-like")
+	}
+	home := t.TempDir()
+	setHome(t, home)
+	binDir := filepath.Join(home, gmDir, "bin")
+
+	block, err := ProfileBlock(ShellZsh, binDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ProfileBegin + "\n" +
+		`export PATH="$HOME/.gm/bin:$PATH"` + "\n" +
+		`eval "$(gm env)"` + "\n" +
+		ProfileEnd + "\n"
+	if block != want {
+		t.Errorf("zsh block:\n%s\nwant:\n%s", block, want)
+	}
+
+	block, _ = ProfileBlock(ShellFish, "/opt/gm")
+	if !strings.Contains(block, "set -gx PATH /opt/gm $PATH\ngm env | source\n") {
+		t.Errorf("fish block:\n%s", block)
+	}
+	block, _ = ProfileBlock(ShellNu, binDir)
+	if !strings.Contains(block, "$env.GOROOT = ('~/.gm/versions/current' | path expand)") {
+		t.Errorf("nu block:\n%s", block)
+	}
+}
+
+func TestAddRemoveProfileBlock(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fish", "config.fish")
+	block := ProfileBegin + "\ngm env | source\n" + ProfileEnd + "\n"
+
+	changed, err := AddProfileBlock(file, block)
+	if err != nil || !changed {
+		t.Fatalf("add to missing file: changed = %v, err = %v", changed, err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != block {
+		t.Errorf("new file = %q, want block only", data)
+	}
+
+	original := "set -x EDITOR vim\n"
+	os.Remove(file)
+	if err := os.WriteFile(file, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 2 {
+		changed, err := AddProfileBlock(file, block)
+		if err != nil || changed != (i == 0) {
+			t.Fatalf("add #%d: changed = %v, err = %v", i+1, changed, err)
+		}
+	}
+	data, _ := os.ReadFile(file)
+	if want := original + "\n" + block; string(data) != want {
+		t.Errorf("profile = %q, want %q", data, want)
+	}
+	if fi, _ := os.Stat(file); runtime.GOOS != "windows" && fi.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", fi.Mode().Perm())
+	}
+
+	// updated block replaces the old one in place
+	updated := ProfileBegin + "\nset -gx PATH /opt/gm $PATH\ngm env | source\n" + ProfileEnd + "\n"
+	if err := os.WriteFile(file, []byte(original+"\n"+block+"alias g git\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddProfileBlock(file, updated); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(file)
+	if want := original + "\n" + updated + "alias g git\n"; string(data) != want {
+		t.Errorf("replaced profile = %q, want %q", data, want)
+	}
+
+	changed, err = RemoveProfileBlock(file)
+	if err != nil || !changed {
+		t.Fatalf("remove: changed = %v, err = %v", changed, err)
+	}
+	data, _ = os.ReadFile(file)
+	if want := original + "alias g git\n"; string(data) != want {
+		t.Errorf("profile after remove = %q, want %q", data, want)
+	}
+	if changed, err := RemoveProfileBlock(file); err != nil || changed {
+		t.Errorf("remove again: changed = %v, err = %v", changed, err)
+	}
+}
+
+func TestRemoveProfileBlock_Legacy(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".bashrc")
+	content := "alias ll='ls -l'\n\n# GM (Go version manager)\nexport PATH=\"$HOME/.gm/bin:$PATH\"\neval $(gm env)\nexport EDITOR=vim\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RemoveProfileBlock(file); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(file)
+	if want := "alias ll='ls -l'\nexport EDITOR=vim\n"; string(data) != want {
+		t.Errorf("profile = %q, want %q", data, want)
+	}
+}
+
+func TestRemoveRoot(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+	external := t.TempDir()
+	if err := os.WriteFile(filepath.Join(external, "VERSION"), []byte("go1.22.0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Link("go1.22.0", external); err != nil {
+		t.Fatal(err)
+	}
+	// module cache is read-only
+	modDir := filepath.Join(home, gmDir, workspace, "pkg", "mod", "example.com", "m@v1.0.0")
+	if err := os.MkdirAll(modDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "go.mod"), []byte("module example.com/m\n"), 0444); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(modDir, 0555); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveRoot(); err != nil {
+		t.Fatalf("RemoveRoot: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, gmDir)); !os.IsNotExist(err) {
+		t.Errorf("gm directory is not removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(external, "VERSION")); err != nil {
+		t.Errorf("external toolchain is removed: %v", err)
+	}
+}
+
+func TestAddProfileBlock_Symlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on Windows")
+	}
+	dotfiles := filepath.Join(t.TempDir(), "zshenv")
+	if err := os.WriteFile(dotfiles, []byte("export EDITOR=vim\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(t.TempDir(), ".zshenv")
+	if err := os.Symlink(dotfiles, link); err != nil {
+		t.Fatal(err)
+	}
+	block := ProfileBegin + "\neval \"$(gm env)\"\n" + ProfileEnd + "\n"
+
+	if _, err := AddProfileBlock(link, block); err != nil {
+		t.Fatalf("AddProfileBlock: %v", err)
+	}
+	if fi, err := os.Lstat(link); err != nil || fi.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("profile link is replaced: %v", err)
+	}
+	if data, _ := os.ReadFile(dotfiles); !strings.Contains(string(data), block) {
+		t.Errorf("link target = %q, want block added", data)
+	}
+	if _, err := RemoveProfileBlock(link); err != nil {
+		t.Fatalf("RemoveProfileBlock: %v", err)
+	}
+	if data, _ := os.ReadFile(dotfiles); string(data) != "export EDITOR=vim\n" {
+		t.Errorf("link target after remove = %q", data)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// PrunePolicy selects installed toolchains for removal. Each enabled rule selects
+// toolchains on its own, toolchain is removed when any of the rules selects it.
+// Current, kept and external toolchains are never selected.
+type PrunePolicy struct {
+	// KeepPatches is a number of the newest patch releases kept per minor line, 0 disables the rule
+	KeepPatches int
+	// Unsupported selects releases of minor lines no longer supported according to Catalog
+	Unsupported bool
+	Catalog     *toolchain.Catalog
+	// UnusedFor selects toolchains not set as current for the given duration, 0 disables the rule
+	UnusedFor time.Duration
+	Now       time.Time
+	// Keep lists versions which are never removed, e.g. pinned by projects
+	Keep []string
+}
+
+// PruneItem is a toolchain selected for removal.
+type PruneItem struct {
+	Toolchain Toolchain
+	Reason    string
+}
+
+// Select returns toolchains selected for removal by policy, newest first.
+func (p PrunePolicy) Select(installed []Toolchain, current *Toolchain) []PruneItem {
+	installed = slices.Clone(installed)
+	gover.SortDesc(installed, func(tc Toolchain) string { return tc.Version })
+
+	patches := make(map[gover.Version]int)
+	var items []PruneItem
+	for _, tc := range installed {
+		v, err := gover.Parse(tc.Version)
+		isRelease := err == nil && !tc.VersionMismatch()
+		if isRelease {
+			patches[v.Lang()]++
+		}
+		if protected(tc, current, p.Keep) {
+			continue
+		}
+
+		var reason string
+		switch {
+		case p.KeepPatches > 0 && isRelease && patches[v.Lang()] > p.KeepPatches:
+			reason = fmt.Sprintf("not among %d newest versions of %s", p.KeepPatches, v.Lang())
+		case p.Unsupported && isRelease && p.Catalog != nil && p.Catalog.Status(tc.Version).Unsupported:
+			reason = fmt.Sprintf("%s is not supported", v.Lang())
+		case p.UnusedFor > 0 && tc.Record != nil && p.Now.Sub(lastUsed(tc)) > p.UnusedFor:
+			reason = "not used since " + lastUsed(tc).Local().Format("2006-01-02")
+		default:
+			continue
+		}
+		items = append(items, PruneItem{Toolchain: tc, Reason: reason})
+	}
+	return items
+}
+
+// SelectOverQuota returns least recently used toolchains which should be removed
+// for total size of installed toolchains to fit quota. Sizes are keyed by version.
+func SelectOverQuota(installed []Toolchain, current *Toolchain, keep []string, sizes map[string]int64, quota int64) []PruneItem {
+	var total int64
+	var candidates []Toolchain
+	for _, tc := range installed {
+		if tc.External {
+			continue
+		}
+		total += sizes[tc.Version]
+		// unfinished installations have no record
+		if tc.Record != nil && !protected(tc, current, keep) {
+			candidates = append(candidates, tc)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b Toolchain) int {
+		return lastUsed(a).Compare(lastUsed(b))
+	})
+
+	var items []PruneItem
+	for _, tc := range candidates {
+		if total <= quota {
+			break
+		}
+		total -= sizes[tc.Version]
+		items = append(items, PruneItem{Toolchain: tc, Reason: "least recently used, disk quota exceeded"})
+	}
+	return items
+}
+
+// protected reports whether toolchain must not be pruned.
+func protected(tc Toolchain, current *Toolchain, keep []string) bool {
+	if tc.External || current != nil && current.Version == tc.Version {
+		return true
+	}
+	return slices.ContainsFunc(keep, func(v string) bool {
+		return strings.TrimPrefix(v, "go") == tc.Version
+	})
+}
+
+// lastUsed returns time toolchain was used last time (set as current, run with
+// gm exec or activated by shell hook), or installation time if never.
+func lastUsed(tc Toolchain) time.Time {
+	if tc.Record == nil {
+		return time.Time{}
+	}
+	if !tc.Record.LastUsedAt.IsZero() {
+		return tc.Record.LastUsedAt
+	}
+	return tc.Record.InstalledAt
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x-dvr/gm/toolchain"
+)
+
+var pruneNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// usedTC returns installed toolchain last used the given number of days before pruneNow.
+func usedTC(version string, daysAgo int) Toolchain {
+	return Toolchain{
+		Version: version,
+		Record: &toolchain.InstallRecord{
+			Version:     "go" + version,
+			InstalledAt: pruneNow.AddDate(0, -6, 0),
+			LastUsedAt:  pruneNow.AddDate(0, 0, -daysAgo),
+		},
+	}
+}
+
+func pruned(items []PruneItem) []string {
+	var versions []string
+	for _, item := range items {
+		versions = append(versions, item.Toolchain.Version)
+	}
+	return versions
+}
+
+func TestPrunePolicy_Select(t *testing.T) {
+	installed := []Toolchain{
+		usedTC("1.21.1", 100),
+		usedTC("1.22.0", 50),
+		usedTC("1.22.2", 1),
+		usedTC("1.21.3", 10),
+		usedTC("1.21.2", 5),
+		usedTC("1.20.7", 2),
+		usedTC("tip-0123456789", 40),
+		{Version: "1.19.1", External: true},
+	}
+	current := &Toolchain{Version: "1.21.1"}
+	catalog := &toolchain.Catalog{Releases: []toolchain.Release{
+		{Version: "go1.22.2", Stable: true},
+		{Version: "go1.21.3", Stable: true},
+		{Version: "go1.20.7", Stable: true},
+		{Version: "go1.19.1", Stable: true},
+	}}
+
+	tests := []struct {
+		name   string
+		policy PrunePolicy
+		want   []string
+	}{
+		{"keep newest", PrunePolicy{KeepPatches: 1}, []string{"1.22.0", "1.21.2"}},
+		{"unsupported", PrunePolicy{Unsupported: true, Catalog: catalog}, []string{"1.20.7"}},
+		{"unused", PrunePolicy{UnusedFor: 30 * 24 * time.Hour}, []string{"1.22.0", "tip-0123456789"}},
+		{"pinned", PrunePolicy{KeepPatches: 1, Keep: []string{"go1.21.2"}}, []string{"1.22.0"}},
+		{"combined", PrunePolicy{KeepPatches: 2, Unsupported: true, Catalog: catalog, UnusedFor: 45 * 24 * time.Hour},
+			[]string{"1.22.0", "1.20.7"}},
+	}
+	for _, tt := range tests {
+		tt.policy.Now = pruneNow
+		got := pruned(tt.policy.Select(installed, current))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: pruned = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSelectOverQuota(t *testing.T) {
+	installed := []Toolchain{
+		usedTC("1.22.2", 1),
+		usedTC("1.22.0", 50),
+		usedTC("1.21.3", 10),
+		usedTC("1.20.7", 100),
+		{Version: "1.19.1", External: true},
+	}
+	sizes := map[string]int64{"1.22.2": 100, "1.22.0": 100, "1.21.3": 100, "1.20.7": 100, "1.19.1": 1000}
+	current := &Toolchain{Version: "1.20.7"}
+
+	got := pruned(SelectOverQuota(installed, current, nil, sizes, 250))
+	if want := []string{"1.22.0", "1.21.3"}; !slices.Equal(got, want) {
+		t.Errorf("evicted = %v, want %v", got, want)
+	}
+	if got := SelectOverQuota(installed, current, nil, sizes, 400); len(got) != 0 {
+		t.Errorf("evicted = %v, want none within quota", pruned(got))
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"slices"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// RequiredVersion is a Go version required by project files.
+type RequiredVersion struct {
+	// Version with "go" prefix
+	Version string
+	// Minimum is set for go directives, newest installed release satisfies them when it is not older
+	Minimum bool
+	// Files require the version
+	Files []string
+	// Installed is toolchain satisfying requirement, nil if it is missing
+	Installed *Toolchain
+	// Install is release to install when requirement is missing
+	Install string
+}
+
+// CheckRequirements matches requirements found in projects against installed toolchains.
+// It returns required versions, newest first, and installed toolchains managed by gm
+// no project needs. Missing minimum requirements are satisfied by the newest patch
+// release of their minor line when catalog is given.
+func CheckRequirements(installed []Toolchain, reqs []project.Requirement, catalog *toolchain.Catalog) ([]RequiredVersion, []Toolchain) {
+	var required []RequiredVersion
+	for _, r := range reqs {
+		i := slices.IndexFunc(required, func(rv RequiredVersion) bool {
+			return rv.Version == r.Version && rv.Minimum == r.Minimum
+		})
+		if i < 0 {
+			required = append(required, RequiredVersion{Version: r.Version, Minimum: r.Minimum})
+			i = len(required) - 1
+		}
+		if !slices.Contains(required[i].Files, r.File) {
+			required[i].Files = append(required[i].Files, r.File)
+		}
+	}
+	slices.SortStableFunc(required, func(a, b RequiredVersion) int {
+		if c := gover.Compare(b.Version, a.Version); c != 0 {
+			return c
+		}
+		// exact requirements first
+		switch {
+		case a.Minimum == b.Minimum:
+			return 0
+		case b.Minimum:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	releases := managedReleases(installed)
+	gover.SortDesc(releases, func(tc Toolchain) string { return tc.Version })
+	var newest *Toolchain
+	for _, tc := range releases {
+		if v, _ := gover.Parse(tc.Version); v.IsRelease() {
+			newest = &tc
+			break
+		}
+	}
+
+	needed := make(map[string]bool)
+	for i := range required {
+		rv := &required[i]
+		if rv.Minimum {
+			if newest != nil && gover.Compare(newest.Version, rv.Version) >= 0 {
+				rv.Installed = newest
+			} else {
+				rv.Install = minimumRelease(rv.Version, catalog)
+			}
+		} else {
+			j := slices.IndexFunc(installed, func(tc Toolchain) bool { return "go"+tc.Version == rv.Version })
+			if j >= 0 {
+				rv.Installed = &installed[j]
+			} else {
+				rv.Install = rv.Version
+			}
+		}
+		if rv.Installed != nil {
+			needed[rv.Installed.Version] = true
+		}
+	}
+
+	var unneeded []Toolchain
+	for _, tc := range installed {
+		if !tc.External && !needed[tc.Version] {
+			unneeded = append(unneeded, tc)
+		}
+	}
+	return required, unneeded
+}
+
+// minimumRelease returns release satisfying minimum version: the newest patch
+// release of its minor line from catalog, or the first release of minimum otherwise.
+func minimumRelease(minimum string, catalog *toolchain.Catalog) string {
+	if catalog != nil {
+		if latest := catalog.Status(minimum).Latest; latest != "" && gover.Compare(latest, minimum) >= 0 {
+			return latest
+		}
+	}
+	v, err := gover.Parse(minimum)
+	if err == nil && v.Patch < 0 && v.Kind == "" && gover.Compare(minimum, "go1.21") >= 0 {
+		// language version, since Go 1.21 the first release is X.Y.0
+		return v.String() + ".0"
+	}
+	return minimum
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/x-dvr/gm/project"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+func TestCheckRequirements(t *testing.T) {
+	installed := []Toolchain{
+		{Version: "1.21.13"},
+		{Version: "1.22.4"},
+		{Version: "1.19.2"},
+		{Version: "1.20.1", External: true},
+	}
+	reqs := []project.Requirement{
+		{Version: "go1.21", Minimum: true, File: "a/go.mod"},
+		{Version: "go1.21.13", File: "b/.go-version"},
+		{Version: "go1.21.13", File: "c/go.mod"},
+		{Version: "go1.20.14", File: "d/.tool-versions"},
+		{Version: "go1.23", Minimum: true, File: "e/go.work"},
+		{Version: "go1.21", Minimum: true, File: "a/go.mod"},
+	}
+
+	required, unneeded := CheckRequirements(installed, reqs, nil)
+	type result struct {
+		version   string
+		minimum   bool
+		files     []string
+		installed string
+		install   string
+	}
+	want := []result{
+		{"go1.23", true, []string{"e/go.work"}, "", "go1.23.0"},
+		{"go1.21.13", false, []string{"b/.go-version", "c/go.mod"}, "1.21.13", ""},
+		{"go1.21", true, []string{"a/go.mod"}, "1.22.4", ""},
+		{"go1.20.14", false, []string{"d/.tool-versions"}, "", "go1.20.14"},
+	}
+	if len(required) != len(want) {
+		t.Fatalf("required = %+v, want %d versions", required, len(want))
+	}
+	for i, w := range want {
+		rv := required[i]
+		got := result{rv.Version, rv.Minimum, rv.Files, "", rv.Install}
+		if rv.Installed != nil {
+			got.installed = rv.Installed.Version
+		}
+		if got.version != w.version || got.minimum != w.minimum || !slices.Equal(got.files, w.files) ||
+			got.installed != w.installed || got.install != w.install {
+			t.Errorf("required[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+	var names []string
+	for _, tc := range unneeded {
+		names = append(names, tc.Version)
+	}
+	if want := []string{"1.19.2"}; !slices.Equal(names, want) {
+		t.Errorf("unneeded = %v, want %v", names, want)
+	}
+
+	catalog := &toolchain.Catalog{Releases: []toolchain.Release{
+		{Version: "go1.23.2", Stable: true},
+		{Version: "go1.22.4", Stable: true},
+	}}
+	required, _ = CheckRequirements(installed, reqs[4:5], catalog)
+	if len(required) != 1 || required[0].Install != "go1.23.2" {
+		t.Errorf("required = %+v, want go1.23.2 to install", required)
+	}
+}
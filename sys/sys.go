@@ -9,23 +9,59 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/toolchain"
 )
 
 const (
-	gmDir     = ".gm"
-	workspace = "workspace"
-	versions  = "versions"
-	current   = "current"
+	gmDir      = ".gm"
+	workspace  = "workspace"
+	versions   = "versions"
+	current    = "current"
+	sources    = "src"
+	goRepo     = "go.git"
+	tipPrefix  = "gotip-"
+	sumdbDir   = "sumdb"
+	proxyDir   = "proxy"
+	catalog    = "releases.json"
+	config     = "config.json"
+	vulnDir    = "vulndb"
+	policyLog  = "policy.log"
+	bisectFile = "bisect.json"
+	benchDir   = "bench"
+	apiDir     = "api"
 )
 
 var (
-	ErrNoPath       = errors.New("environment variable 'PATH' is not set")
-	ErrNotInstalled = errors.New("version is not installed")
+	ErrNoPath           = errors.New("environment variable 'PATH' is not set")
+	ErrNotInstalled     = errors.New("version is not installed")
+	ErrNoBootstrap      = errors.New("no installed version satisfies bootstrap requirement")
+	ErrInstalled        = errors.New("version is already installed")
+	ErrIsCurrent        = errors.New("version is set as current")
+	ErrUnsupportedShell = errors.New("unsupported shell")
+	ErrInvalidName      = errors.New("invalid version name")
 )
 
 type Toolchain struct {
 	Version string
 	Path    string
+	// External is set for toolchains registered with Link,
+	// Path points to the location of toolchain outside of gm.
+	External bool
+	// Record describes installation of toolchain, it is nil for
+	// external toolchains and unfinished installations.
+	Record *toolchain.InstallRecord
+}
+
+// VersionMismatch reports whether toolchain directory is named after
+// another version than recorded at install time.
+func (t Toolchain) VersionMismatch() bool {
+	if t.Record == nil || !gover.IsValid(t.Record.Version) {
+		// development builds are named after commit
+		return false
+	}
+	return "go"+t.Version != t.Record.Version
 }
 
 func PathForVersion(version string) (string, error) {
@@ -36,6 +72,131 @@ func PathForVersion(version string) (string, error) {
 	return filepath.Join(homedir, gmDir, versions, version), nil
 }
 
+// ValidateVersionName checks that name given by user or read from foreign
+// toolchain can name a directory of toolchain. Names with path separators
+// or "..", and reserved name of current version are rejected.
+func ValidateVersionName(name string) error {
+	if name == "" || name == "." || name == current ||
+		strings.Contains(name, "..") || strings.ContainsAny(name, `/\`) || filepath.Base(name) != name {
+		return fmt.Errorf("%w %q", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// PathForName returns path of toolchain directory named name, e.g. read from
+// VERSION file of untrusted tree, making sure it is an entry of versions directory.
+func PathForName(name string) (string, error) {
+	if err := ValidateVersionName(name); err != nil {
+		return "", err
+	}
+	versionsPath, err := PathForVersion("")
+	if err != nil {
+		return "", err
+	}
+	versionPath := filepath.Join(versionsPath, name)
+	if filepath.Dir(versionPath) != filepath.Clean(versionsPath) {
+		return "", fmt.Errorf("%w %q", ErrInvalidName, name)
+	}
+	return versionPath, nil
+}
+
+// SourcePath returns path of the local clone of Go repository used to build toolchains from source.
+func SourcePath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, sources, goRepo), nil
+}
+
+// SumDBCachePath returns path of the directory where verified checksum database data is cached.
+func SumDBCachePath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, sumdbDir), nil
+}
+
+// ProxyCachePath returns path of the directory where toolchain proxy caches module zips.
+func ProxyCachePath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, proxyDir), nil
+}
+
+// CatalogPath returns path of the file where catalog of Go releases is cached.
+func CatalogPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, catalog), nil
+}
+
+// VulnCachePath returns path of the directory where vulnerability database is cached.
+func VulnCachePath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, vulnDir), nil
+}
+
+// ConfigPath returns path of gm configuration file.
+func ConfigPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, config), nil
+}
+
+// PolicyLogPath returns path of the file logging versions used against policy with --ignore-policy.
+func PolicyLogPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, policyLog), nil
+}
+
+// BisectPath returns path of the file where state of 'gm bisect' is saved.
+func BisectPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, bisectFile), nil
+}
+
+// BenchPath returns path of the directory where results of 'gm bench' are saved.
+func BenchPath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, benchDir), nil
+}
+
+// APICachePath returns path of the directory where fetched API files of Go releases are cached.
+func APICachePath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir of user: %w", err)
+	}
+	return filepath.Join(homedir, gmDir, apiDir), nil
+}
+
+// TipVersion returns version name for toolchain built from the given commit.
+func TipVersion(shortCommit string) string {
+	return tipPrefix + shortCommit
+}
+
+// ListInstalledVersions returns installed toolchains sorted by version, newest first.
+// Toolchains which are not named after Go release (e.g. tip builds) come last.
 func ListInstalledVersions() ([]Toolchain, error) {
 	homedir, err := os.UserHomeDir()
 	if err != nil {
@@ -54,13 +215,33 @@ func ListInstalledVersions() ([]Toolchain, error) {
 
 	var installed []Toolchain
 	for _, entry := range entries {
-		if entry.IsDir() && strings.HasPrefix(entry.Name(), "go") {
-			installed = append(installed, Toolchain{
-				Path:    filepath.Join(versionsPath, entry.Name()),
+		if !strings.HasPrefix(entry.Name(), "go") {
+			continue
+		}
+		entryPath := filepath.Join(versionsPath, entry.Name())
+		switch {
+		case entry.IsDir():
+			tc := Toolchain{
+				Path:    entryPath,
 				Version: strings.TrimPrefix(entry.Name(), "go"),
+			}
+			if rec, err := toolchain.ReadRecord(entryPath); err == nil {
+				tc.Record = rec
+			}
+			installed = append(installed, tc)
+		case isLink(entry.Type()):
+			target, err := os.Readlink(entryPath)
+			if err != nil {
+				return nil, fmt.Errorf("read link of external version %s: %w", entry.Name(), err)
+			}
+			installed = append(installed, Toolchain{
+				Path:     target,
+				Version:  strings.TrimPrefix(entry.Name(), "go"),
+				External: true,
 			})
 		}
 	}
+	gover.SortDesc(installed, func(tc Toolchain) string { return tc.Version })
 	return installed, nil
 }
 
@@ -81,11 +262,20 @@ func SetAsCurrent(version string) error {
 		return fmt.Errorf("check installed version: %w", err)
 	}
 
+	previous, _ := GetCurrentVersion()
 	if err := os.Remove(currentPath); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("reset current version: %w", err)
 	}
+	if err := createSymlink(versionPath, currentPath); err != nil {
+		return err
+	}
 
-	return createSymlink(versionPath, currentPath)
+	// usage tracking is best effort, external toolchains have no record
+	if previous != nil && previous.Path != versionPath {
+		toolchain.TouchLastUsed(previous.Path)
+	}
+	toolchain.TouchLastUsed(versionPath)
+	return nil
 }
 
 func GetCurrentVersion() (*Toolchain, error) {
@@ -110,3 +300,58 @@ func GetCurrentVersion() (*Toolchain, error) {
 
 	return &t, nil
 }
+
+// FindBootstrap returns the newest installed release of Go toolchain
+// which can be used to build Go from source requiring minVersion.
+func FindBootstrap(minVersion string) (*Toolchain, error) {
+	installed, err := ListInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tc := range installed {
+		if !gover.IsValid(tc.Version) || tc.VersionMismatch() || gover.Compare(tc.Version, minVersion) < 0 {
+			continue
+		}
+		return &tc, nil
+	}
+	return nil, fmt.Errorf("%w (%s or later)", ErrNoBootstrap, minVersion)
+}
+
+// LatestInstalled returns the newest installed stable release of Go toolchain.
+func LatestInstalled() (*Toolchain, error) {
+	installed, err := ListInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tc := range installed {
+		v, err := gover.Parse(tc.Version)
+		if err != nil || !v.IsRelease() || tc.VersionMismatch() {
+			continue
+		}
+		return &tc, nil
+	}
+	return nil, ErrNotInstalled
+}
+
+// FindLatestTip returns the most recently built toolchain from Go source repository,
+// or nil if there is none.
+func FindLatestTip() (*Toolchain, error) {
+	installed, err := ListInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Toolchain
+	for _, tc := range installed {
+		if !strings.HasPrefix("go"+tc.Version, tipPrefix) || tc.Record == nil {
+			// not a source build or build was not completed
+			continue
+		}
+		if found == nil || tc.Record.InstalledAt.After(found.Record.InstalledAt) {
+			found = &tc
+		}
+	}
+	return found, nil
+}
@@ -8,7 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/x-dvr/gm/toolchain"
 )
 
 // setHome overrides the user's home directory for the duration of the test.
@@ -36,6 +40,31 @@ func TestPathForVersion(t *testing.T) {
 	}
 }
 
+func TestPathForName(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	got, err := PathForName("go1.22.0")
+	if want := filepath.Join(home, gmDir, versions, "go1.22.0"); err != nil || got != want {
+		t.Errorf("PathForName(go1.22.0) = %q, %v, want %q", got, err, want)
+	}
+
+	// VERSION of crafted source archive must not escape versions directory or replace current link
+	tree := t.TempDir()
+	for _, version := range []string{"../../x", "current", "go1.22.0/../../x", `..\x`} {
+		if err := os.WriteFile(filepath.Join(tree, "VERSION"), []byte(version+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		name, err := toolchain.ReadVersion(tree)
+		if err != nil {
+			t.Fatalf("ReadVersion: %v", err)
+		}
+		if got, err := PathForName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("PathForName(%q) = %q, %v, want ErrInvalidName", name, got, err)
+		}
+	}
+}
+
 func TestListInstalledVersions_NoDirectory(t *testing.T) {
 	home := t.TempDir()
 	setHome(t, home)
@@ -167,3 +196,149 @@ func TestSetAsCurrent_NotInstalled(t *testing.T) {
 		t.Errorf("err = %v, want ErrNotInstalled", err)
 	}
 }
+
+func TestFindBootstrap(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	versionsDir := filepath.Join(home, gmDir, versions)
+	for _, d := range []string{"go1.20.14", "go1.22.6", "go1.21.0", "gotip-0123456789"} {
+		if err := os.MkdirAll(filepath.Join(versionsDir, d), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	tc, err := FindBootstrap("go1.20")
+	if err != nil {
+		t.Fatalf("FindBootstrap: %v", err)
+	}
+	if tc.Version != "1.22.6" {
+		t.Errorf("Version = %q, want newest release 1.22.6", tc.Version)
+	}
+
+	if _, err := FindBootstrap("go1.24.6"); !errors.Is(err, ErrNoBootstrap) {
+		t.Errorf("err = %v, want ErrNoBootstrap", err)
+	}
+}
+
+func TestListInstalledVersions_Sorted(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	versionsDir := filepath.Join(home, gmDir, versions)
+	for _, d := range []string{"go1.9.7", "gotip-0123456789", "go1.22.0", "go1.10.1", "go1.23rc2"} {
+		if err := os.MkdirAll(filepath.Join(versionsDir, d), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	installed, err := ListInstalledVersions()
+	if err != nil {
+		t.Fatalf("ListInstalledVersions: %v", err)
+	}
+	var got []string
+	for _, tc := range installed {
+		got = append(got, tc.Version)
+	}
+	want := []string{"1.23rc2", "1.22.0", "1.10.1", "1.9.7", "tip-0123456789"}
+	if !slices.Equal(got, want) {
+		t.Errorf("versions = %v, want %v", got, want)
+	}
+
+	tc, err := LatestInstalled()
+	if err != nil {
+		t.Fatalf("LatestInstalled: %v", err)
+	}
+	if tc.Version != "1.22.0" {
+		t.Errorf("LatestInstalled = %q, want newest stable release 1.22.0", tc.Version)
+	}
+}
+
+func TestLatestInstalled_None(t *testing.T) {
+	setHome(t, t.TempDir())
+	if _, err := LatestInstalled(); !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("err = %v, want ErrNotInstalled", err)
+	}
+}
+
+func TestFindLatestTip(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	tc, err := FindLatestTip()
+	if err != nil {
+		t.Fatalf("FindLatestTip (none): %v", err)
+	}
+	if tc != nil {
+		t.Errorf("got %+v, want nil without source builds", tc)
+	}
+
+	versionsDir := filepath.Join(home, gmDir, versions)
+	old := time.Now().Add(-time.Hour)
+	for _, d := range []string{"go1.22.0", "gotip-aaaaaaaaaa", "gotip-bbbbbbbbbb", "gotip-cccccccccc"} {
+		if err := os.MkdirAll(filepath.Join(versionsDir, d), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if d == "gotip-cccccccccc" {
+			// unfinished build
+			continue
+		}
+		// marker written by older versions of gm
+		marker := filepath.Join(versionsDir, d, ".install-success")
+		if err := os.WriteFile(marker, nil, 0644); err != nil {
+			t.Fatalf("write marker: %v", err)
+		}
+		if d == "gotip-aaaaaaaaaa" {
+			if err := os.Chtimes(marker, old, old); err != nil {
+				t.Fatalf("chtimes: %v", err)
+			}
+		}
+	}
+
+	tc, err = FindLatestTip()
+	if err != nil {
+		t.Fatalf("FindLatestTip: %v", err)
+	}
+	if tc == nil || tc.Version != "tip-bbbbbbbbbb" {
+		t.Errorf("got %+v, want tip-bbbbbbbbbb", tc)
+	}
+}
+
+func TestListInstalledVersions_Record(t *testing.T) {
+	home := t.TempDir()
+	setHome(t, home)
+
+	versionsDir := filepath.Join(home, gmDir, versions)
+	for dir, version := range map[string]string{"go1.22.4": "go1.22.4", "go1.22.0": "go1.22.5"} {
+		goroot := filepath.Join(versionsDir, dir)
+		if err := os.MkdirAll(goroot, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(goroot, "VERSION"), []byte(version), 0644); err != nil {
+			t.Fatalf("write VERSION: %v", err)
+		}
+		if err := toolchain.MarkInstalled(goroot, toolchain.InstallRecord{}); err != nil {
+			t.Fatalf("MarkInstalled: %v", err)
+		}
+	}
+
+	if err := SetAsCurrent("go1.22.4"); err != nil {
+		t.Fatalf("SetAsCurrent: %v", err)
+	}
+
+	installed, err := ListInstalledVersions()
+	if err != nil {
+		t.Fatalf("ListInstalledVersions: %v", err)
+	}
+	for _, tc := range installed {
+		if tc.Record == nil {
+			t.Fatalf("%s has no record", tc.Version)
+		}
+		if mismatch := tc.Version == "1.22.0"; tc.VersionMismatch() != mismatch {
+			t.Errorf("%s: VersionMismatch = %v, want %v", tc.Version, tc.VersionMismatch(), mismatch)
+		}
+		if used := tc.Version == "1.22.4"; tc.Record.LastUsedAt.IsZero() == used {
+			t.Errorf("%s: LastUsedAt = %v", tc.Version, tc.Record.LastUsedAt)
+		}
+	}
+}
@@ -12,7 +12,9 @@ import (
 	"strings"
 )
 
-func PrepareGoEnvs() error {
+// PrepareGoEnvs outputs shell commands setting Go environment variables.
+// If goproxy is not empty, GOPROXY is set to it as well.
+func PrepareGoEnvs(goproxy string) error {
 	path := os.Getenv("PATH")
 	if path == "" {
 		return ErrNoPath
@@ -37,16 +39,107 @@ func PrepareGoEnvs() error {
 		fmt.Printf("set -gx GOBIN %s\n", goBin)
 		fmt.Printf("set -gx GOROOT %s\n", goRoot)
 		fmt.Printf("set -gx PATH %s $PATH\n", goSDKBin+":"+goBin)
+		if goproxy != "" {
+			fmt.Printf("set -gx GOPROXY %s\n", goproxy)
+		}
 	} else {
 		// Bash/Zsh/POSIX shell syntax
 		fmt.Printf("export GOPATH=%s\n", goPath)
 		fmt.Printf("export GOBIN=%s\n", goBin)
 		fmt.Printf("export GOROOT=%s\n", goRoot)
 		fmt.Printf("export PATH=\"%s:$PATH\"\n", goSDKBin+":"+goBin)
+		if goproxy != "" {
+			fmt.Printf("export GOPROXY=%s\n", goproxy)
+		}
 	}
 	return nil
 }
 
+// PrintToolchainEnvs outputs shell commands setting GOROOT and PATH to use toolchain in goRoot.
+// Empty goRoot unsets GOROOT and removes toolchains managed by gm from PATH.
+func PrintToolchainEnvs(goRoot string) error {
+	env, err := ToolchainEnv(goRoot, []string{"PATH=" + os.Getenv("PATH")})
+	if err != nil {
+		return err
+	}
+	isFish := strings.HasSuffix(os.Getenv("SHELL"), "/fish")
+	if goRoot == "" && isFish {
+		fmt.Println("set -e GOROOT")
+	} else if goRoot == "" {
+		fmt.Println("unset GOROOT")
+	}
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		if isFish && name == "PATH" {
+			fmt.Printf("set -gx PATH %s\n", strings.Join(filepath.SplitList(value), " "))
+		} else if isFish {
+			fmt.Printf("set -gx %s %s\n", name, value)
+		} else {
+			fmt.Printf("export %s=\"%s\"\n", name, value)
+		}
+	}
+	return nil
+}
+
+// PrintShellHook outputs shell hook switching toolchain to the version pinned
+// by project in working directory. It is evaluated on each prompt (bash, zsh)
+// or change of working directory (fish).
+func PrintShellHook() error {
+	switch shell := filepath.Base(os.Getenv("SHELL")); shell {
+	case "fish":
+		fmt.Println(`function _gm_hook --on-variable PWD
+    gm env --project | source
+end
+_gm_hook`)
+	case "zsh":
+		fmt.Println(`_gm_hook() { eval "$(gm env --project)"; }
+typeset -ag precmd_functions
+if (( ! ${precmd_functions[(I)_gm_hook]} )); then
+  precmd_functions=(_gm_hook $precmd_functions)
+fi`)
+	case "bash", "sh":
+		fmt.Println(`_gm_hook() { eval "$(gm env --project)"; }
+if [[ ";${PROMPT_COMMAND:-};" != *";_gm_hook;"* ]]; then
+  PROMPT_COMMAND="_gm_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi`)
+	default:
+		return fmt.Errorf("%w %q (supported: bash, zsh, fish)", ErrUnsupportedShell, shell)
+	}
+	return nil
+}
+
+// EnvCommand returns shell command setting up environment with gm env.
+func EnvCommand() string {
+	if strings.HasSuffix(os.Getenv("SHELL"), "/fish") {
+		return "'gm env | source'"
+	}
+	return `'eval "$(gm env)"'`
+}
+
+// ShellProfiles returns startup files of user shell which may set up environment.
+func ShellProfiles() ([]string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("get home dir of user: %w", err)
+	}
+	shell, err := ParseShell(os.Getenv("SHELL"))
+	if err != nil {
+		return []string{filepath.Join(homedir, ".profile")}, nil
+	}
+	profiles, err := ProfileFiles(shell)
+	if err != nil {
+		return nil, err
+	}
+	switch shell {
+	case ShellBash:
+		profiles = append(profiles, filepath.Join(homedir, ".profile"))
+	case ShellZsh:
+		dir := filepath.Dir(profiles[0])
+		profiles = append(profiles, filepath.Join(dir, ".zshrc"), filepath.Join(dir, ".zprofile"))
+	}
+	return profiles, nil
+}
+
 func createSymlink(target, link string) error {
 	return os.Symlink(target, link)
 }
@@ -29,7 +29,9 @@ const (
 	SMTO_ABORTIFHUNG = 0x0002
 )
 
-func PrepareGoEnvs() error {
+// PrepareGoEnvs sets Go environment variables for the user.
+// If goproxy is not empty, GOPROXY is set to it as well.
+func PrepareGoEnvs(goproxy string) error {
 	path := os.Getenv("PATH")
 	if path == "" {
 		return ErrNoPath
@@ -55,6 +57,12 @@ func PrepareGoEnvs() error {
 		return fmt.Errorf("set GOROOT: %w", err)
 	}
 
+	if goproxy != "" {
+		if err := setUserEnv("GOPROXY", goproxy); err != nil {
+			return fmt.Errorf("set GOPROXY: %w", err)
+		}
+	}
+
 	// Update PATH - prepend Go SDK and Go bin if not already present
 	newPathEntries := []string{goSDKBin, goBin}
 	pathParts := strings.Split(path, ";")
@@ -84,11 +92,34 @@ func PrepareGoEnvs() error {
 	fmt.Printf("   GOPATH: %s\n", goPath)
 	fmt.Printf("   GOBIN: %s\n", goBin)
 	fmt.Printf("   GOROOT: %s\n", goRoot)
+	if goproxy != "" {
+		fmt.Printf("   GOPROXY: %s\n", goproxy)
+	}
 	fmt.Println("\nNote: Restart your terminal for changes to take effect in new sessions")
 
 	return nil
 }
 
+// PrintToolchainEnvs is not supported on Windows, environment variables are set for the user.
+func PrintToolchainEnvs(goRoot string) error {
+	return fmt.Errorf("%w: per-project environment is not supported on Windows, use 'gm exec'", ErrUnsupportedShell)
+}
+
+// PrintShellHook is not supported on Windows.
+func PrintShellHook() error {
+	return fmt.Errorf("%w: shell hook is not supported on Windows, use 'gm exec'", ErrUnsupportedShell)
+}
+
+// EnvCommand returns command setting up environment with gm env.
+func EnvCommand() string {
+	return "'gm env'"
+}
+
+// ShellProfiles returns no files on Windows, environment variables are set for the user.
+func ShellProfiles() ([]string, error) {
+	return nil, nil
+}
+
 // setUserEnv sets a user-level environment variable in the Windows registry
 func setUserEnv(name, value string) error {
 	key, err := registry.OpenKey(registry.CURRENT_USER, `Environment`, registry.SET_VALUE)
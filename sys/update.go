@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"github.com/x-dvr/gm/gover"
+	"github.com/x-dvr/gm/toolchain"
+)
+
+// Update is a newer patch release available for installed minor line.
+type Update struct {
+	// Lang is minor release line, e.g. go1.22
+	Lang gover.Version
+	// Installed is the newest installed version of the line
+	Installed Toolchain
+	// Latest is the newest published release of the line, e.g. go1.22.4
+	Latest string
+	// Superseded are installed versions of the line older than Latest
+	Superseded []Toolchain
+}
+
+// FindUpdates returns updates available for minor lines of installed releases, newest line first.
+// External toolchains and toolchains which are not releases are not updated.
+func FindUpdates(installed []Toolchain, catalog *toolchain.Catalog) []Update {
+	var updates []Update
+	for _, group := range gover.GroupByLang(managedReleases(installed), func(tc Toolchain) string { return tc.Version }) {
+		newest := group.Items[0]
+		st := catalog.Status(newest.Version)
+		if !st.Outdated {
+			continue
+		}
+		updates = append(updates, Update{
+			Lang:       group.Lang,
+			Installed:  newest,
+			Latest:     st.Latest,
+			Superseded: group.Items,
+		})
+	}
+	return updates
+}
+
+// managedReleases returns installed releases managed by gm.
+func managedReleases(installed []Toolchain) []Toolchain {
+	var releases []Toolchain
+	for _, tc := range installed {
+		if !tc.External && gover.IsValid(tc.Version) && !tc.VersionMismatch() {
+			releases = append(releases, tc)
+		}
+	}
+	return releases
+}
@@ -0,0 +1,42 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package sys
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/x-dvr/gm/toolchain"
+)
+
+func TestFindUpdates(t *testing.T) {
+	installed := []Toolchain{
+		{Version: "1.22.0"},
+		{Version: "1.22.2"},
+		{Version: "1.21.8"},
+		{Version: "1.20.1", External: true},
+		{Version: "tip-0123456789"},
+	}
+	catalog := &toolchain.Catalog{Releases: []toolchain.Release{
+		{Version: "go1.22.4", Stable: true},
+		{Version: "go1.21.8", Stable: true},
+		{Version: "go1.20.14", Stable: true},
+	}}
+
+	updates := FindUpdates(installed, catalog)
+	if len(updates) != 1 {
+		t.Fatalf("updates = %+v, want single update of go1.22", updates)
+	}
+	u := updates[0]
+	if u.Lang.String() != "go1.22" || u.Installed.Version != "1.22.2" || u.Latest != "go1.22.4" {
+		t.Errorf("update = %+v, want 1.22.2 -> go1.22.4", u)
+	}
+	var superseded []string
+	for _, tc := range u.Superseded {
+		superseded = append(superseded, tc.Version)
+	}
+	if want := []string{"1.22.2", "1.22.0"}; !slices.Equal(superseded, want) {
+		t.Errorf("superseded = %v, want %v", superseded, want)
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// CatalogMaxAge is how long cached release catalog is used before it is fetched again.
+const CatalogMaxAge = 24 * time.Hour
+
+// supportedLines is a number of the most recent minor release lines supported by Go team.
+const supportedLines = 2
+
+var catalogURL = fmt.Sprintf("https://%s/dl/?mode=json&include=all", goDevHost)
+
+// Release is a Go release published in release catalog.
+type Release struct {
+	Version string `json:"version"`
+	Stable  bool   `json:"stable"`
+	// Files are published only by Go download server, releases
+	// listed by module proxy have none
+	Files []File `json:"files,omitempty"`
+}
+
+// File is a downloadable file of Go release.
+type File struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	// Kind is one of "archive", "installer" or "source"
+	Kind string `json:"kind"`
+}
+
+// Catalog is a list of published Go releases.
+type Catalog struct {
+	Releases  []Release `json:"releases"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Status describes how installed version relates to published releases.
+type Status struct {
+	// Latest is the newest stable release of the same minor line,
+	// empty if the line has no stable releases yet
+	Latest string
+	// Outdated is set if a newer patch release of the same minor line is published
+	Outdated bool
+	// Unsupported is set if the minor line is not one of two most recent ones
+	Unsupported bool
+	// UnsupportedSince is the first release of minor line which ended support
+	// of the version, e.g. go1.22.0 for go1.20.x
+	UnsupportedSince string
+}
+
+// LoadCatalog returns release catalog cached in cacheFile, fetching it again when
+// the cache is older than maxAge. Go download server is asked first, then module proxies
+// from cfg are asked for versions of golang.org/toolchain module.
+// If the catalog can not be fetched, stale cache is returned if there is one.
+func LoadCatalog(cacheFile string, maxAge time.Duration, cfg ProxyConfig) (*Catalog, error) {
+	cached, cacheErr := readCatalog(cacheFile)
+	if cacheErr == nil && time.Since(cached.FetchedAt) < maxAge {
+		return cached, nil
+	}
+
+	c, err := FetchCatalog(cfg)
+	if err != nil {
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("encode release catalog: %w", err)
+	}
+	if err := writeFileAtomic(cacheFile, data); err != nil {
+		return nil, fmt.Errorf("cache release catalog: %w", err)
+	}
+	return c, nil
+}
+
+// CachedCatalog returns release catalog cached by LoadCatalog regardless of its age.
+func CachedCatalog(cacheFile string) (*Catalog, error) {
+	return readCatalog(cacheFile)
+}
+
+// FetchCatalog downloads list of Go releases.
+func FetchCatalog(cfg ProxyConfig) (*Catalog, error) {
+	releases, err := fetchDLCatalog(catalogURL)
+	if err != nil {
+		var proxyErr error
+		releases, proxyErr = fetchProxyCatalog(cfg)
+		if proxyErr != nil {
+			return nil, fmt.Errorf("fetch release catalog: %w", errors.Join(err, proxyErr))
+		}
+	}
+	gover.SortDesc(releases, func(r Release) string { return r.Version })
+	return &Catalog{Releases: releases, FetchedAt: time.Now().UTC()}, nil
+}
+
+func readCatalog(file string) (*Catalog, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("decode release catalog %s: %w", file, err)
+	}
+	return &c, nil
+}
+
+// fetchDLCatalog reads releases from JSON list of Go download server.
+func fetchDLCatalog(url string) ([]Release, error) {
+	data, err := fetchURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", url, err)
+	}
+	var releases []Release
+	if err := json.Unmarshal(data, &releases); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", url, err)
+	}
+	return releases, nil
+}
+
+// fetchProxyCatalog reads releases from the list of golang.org/toolchain module versions
+// for the current platform.
+func fetchProxyCatalog(cfg ProxyConfig) ([]Release, error) {
+	proxies, err := parseGoProxy(cfg.GoProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, p := range proxies {
+		url := fmt.Sprintf("%s/%s/@v/list", p.url, ToolchainModule)
+		data, err := fetchURL(url)
+		if err == nil {
+			return parseModuleList(string(data)), nil
+		}
+		errs = append(errs, fmt.Errorf("get %s: %w", url, err))
+
+		var se *statusError
+		notFound := errors.As(err, &se) && (se.code == http.StatusNotFound || se.code == http.StatusGone)
+		if !p.fallback && !notFound {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// parseModuleList converts versions of golang.org/toolchain module to releases.
+func parseModuleList(list string) []Release {
+	var releases []Release
+	for _, line := range strings.Fields(list) {
+		version, ok := ParseModuleVersion(line)
+		if !ok {
+			continue
+		}
+		v, err := gover.Parse(version)
+		if err != nil {
+			continue
+		}
+		releases = append(releases, Release{Version: version, Stable: v.IsRelease()})
+	}
+	return releases
+}
+
+func fetchURL(url string) ([]byte, error) {
+	res, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, &statusError{url: url, status: res.Status, code: res.StatusCode}
+	}
+	return io.ReadAll(res.Body)
+}
+
+// Archive returns binary archive of release for the given platform.
+func (c *Catalog) Archive(version, goos, goarch string) (File, bool) {
+	if goos == "linux" && goarch == "arm" {
+		goarch = "armv6l"
+	}
+	version = "go" + strings.TrimPrefix(version, "go")
+	for _, r := range c.Releases {
+		if r.Version != version {
+			continue
+		}
+		for _, f := range r.Files {
+			if f.Kind == "archive" && f.OS == goos && f.Arch == goarch {
+				return f, true
+			}
+		}
+	}
+	return File{}, false
+}
+
+// Latest returns the newest stable release in the catalog, empty if there is none.
+func (c *Catalog) Latest() string {
+	latest := ""
+	for _, r := range c.Releases {
+		if v, err := gover.Parse(r.Version); err == nil && r.Stable && v.IsRelease() &&
+			(latest == "" || gover.Compare(r.Version, latest) > 0) {
+			latest = r.Version
+		}
+	}
+	return latest
+}
+
+// Status returns status of the given version against the catalog.
+// Versions which are not Go releases have zero status.
+func (c *Catalog) Status(version string) Status {
+	v, err := gover.Parse(version)
+	if err != nil {
+		return Status{}
+	}
+	lang := v.Lang()
+
+	releases := slices.Clone(c.Releases)
+	gover.SortDesc(releases, func(r Release) string { return r.Version })
+
+	var st Status
+	var lines []gover.Version
+	// first stable release of each minor line
+	first := make(map[gover.Version]string)
+	for _, r := range releases {
+		rv, err := gover.Parse(r.Version)
+		if err != nil || !r.Stable || !rv.IsRelease() {
+			continue
+		}
+		if st.Latest == "" && rv.Lang() == lang {
+			st.Latest = rv.String()
+			st.Outdated = rv.Compare(v) > 0
+		}
+		if n := len(lines); n == 0 || lines[n-1] != rv.Lang() {
+			lines = append(lines, rv.Lang())
+		}
+		first[rv.Lang()] = rv.String()
+	}
+
+	newer := 0
+	for newer < len(lines) && lines[newer].Compare(lang) > 0 {
+		newer++
+	}
+	if newer >= supportedLines {
+		st.Unsupported = true
+		st.UnsupportedSince = first[lines[newer-supportedLines]]
+	}
+	return st
+}
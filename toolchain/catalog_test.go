@@ -0,0 +1,170 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCatalog() *Catalog {
+	return &Catalog{Releases: []Release{
+		{Version: "go1.23rc1"},
+		{Version: "go1.22.1", Stable: true},
+		{Version: "go1.22.0", Stable: true},
+		{Version: "go1.22rc2"},
+		{Version: "go1.21.8", Stable: true},
+		{Version: "go1.21.0", Stable: true},
+		{Version: "go1.20.14", Stable: true},
+	}}
+}
+
+func TestCatalogStatus(t *testing.T) {
+	c := testCatalog()
+	tests := []struct {
+		version string
+		want    Status
+	}{
+		{"1.22.1", Status{Latest: "go1.22.1"}},
+		{"go1.22.0", Status{Latest: "go1.22.1", Outdated: true}},
+		{"1.22rc2", Status{Latest: "go1.22.1", Outdated: true}},
+		{"1.21.8", Status{Latest: "go1.21.8"}},
+		{"1.20.14", Status{Latest: "go1.20.14", Unsupported: true, UnsupportedSince: "go1.22.0"}},
+		{"1.19.2", Status{Unsupported: true, UnsupportedSince: "go1.21.0"}},
+		{"1.23rc1", Status{}},
+		{"tip-0123456789", Status{}},
+	}
+	for _, tt := range tests {
+		if got := c.Status(tt.version); got != tt.want {
+			t.Errorf("Status(%s) = %+v, want %+v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogLatest(t *testing.T) {
+	if got := testCatalog().Latest(); got != "go1.22.1" {
+		t.Errorf("Latest() = %q, want go1.22.1", got)
+	}
+	if got := (&Catalog{Releases: []Release{{Version: "go1.23rc1"}}}).Latest(); got != "" {
+		t.Errorf("Latest() without stable releases = %q, want empty", got)
+	}
+}
+
+func TestCatalogArchive(t *testing.T) {
+	c := &Catalog{Releases: []Release{{
+		Version: "go1.22.4",
+		Stable:  true,
+		Files: []File{
+			{Filename: "go1.22.4.src.tar.gz", SHA256: "src", Kind: "source"},
+			{Filename: "go1.22.4.linux-amd64.tar.gz", OS: "linux", Arch: "amd64", SHA256: "amd64", Kind: "archive"},
+			{Filename: "go1.22.4.linux-armv6l.tar.gz", OS: "linux", Arch: "armv6l", SHA256: "arm", Kind: "archive"},
+			{Filename: "go1.22.4.windows-amd64.msi", OS: "windows", Arch: "amd64", SHA256: "msi", Kind: "installer"},
+			{Filename: "go1.22.4.windows-amd64.zip", OS: "windows", Arch: "amd64", SHA256: "zip", Kind: "archive"},
+		},
+	}}}
+	tests := []struct {
+		version, goos, goarch, want string
+	}{
+		{"1.22.4", "linux", "amd64", "amd64"},
+		{"go1.22.4", "linux", "arm", "arm"},
+		{"go1.22.4", "windows", "amd64", "zip"},
+	}
+	for _, tt := range tests {
+		f, ok := c.Archive(tt.version, tt.goos, tt.goarch)
+		if !ok || f.SHA256 != tt.want {
+			t.Errorf("Archive(%s, %s/%s) = %+v, %v, want sha256 %s", tt.version, tt.goos, tt.goarch, f, ok, tt.want)
+		}
+	}
+	if _, ok := c.Archive("go1.22.4", "darwin", "arm64"); ok {
+		t.Error("Archive: want no archive for darwin/arm64")
+	}
+	if _, ok := c.Archive("go1.21.0", "linux", "amd64"); ok {
+		t.Error("Archive: want no archive for unknown release")
+	}
+}
+
+func TestParseModuleList(t *testing.T) {
+	list := strings.Join([]string{
+		ModuleVersion("go1.22.1").Version,
+		ModuleVersion("go1.23rc1").Version,
+		"v0.0.1-go1.22.1.plan9-mips",
+	}, "\n")
+	got := parseModuleList(list)
+	want := []Release{{Version: "go1.22.1", Stable: true}, {Version: "go1.23rc1"}}
+	if len(got) != len(want) {
+		t.Fatalf("releases = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Version != want[i].Version || got[i].Stable != want[i].Stable {
+			t.Errorf("release %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(testCatalog().Releases)
+	}))
+	t.Cleanup(srv.Close)
+	oldURL := catalogURL
+	catalogURL = srv.URL
+	t.Cleanup(func() { catalogURL = oldURL })
+
+	cacheFile := filepath.Join(t.TempDir(), "releases.json")
+	cfg := ProxyConfig{GoProxy: "off"}
+	c, err := LoadCatalog(cacheFile, time.Hour, cfg)
+	if err != nil {
+		t.Fatalf("LoadCatalog: %v", err)
+	}
+	if len(c.Releases) != 7 || c.Releases[0].Version != "go1.23rc1" {
+		t.Errorf("releases = %+v, want sorted catalog", c.Releases)
+	}
+
+	if _, err := LoadCatalog(cacheFile, time.Hour, cfg); err != nil {
+		t.Fatalf("LoadCatalog (cached): %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want fresh cache to be used", requests)
+	}
+
+	// stale cache is used when catalog is not available
+	srv.Close()
+	c, err = LoadCatalog(cacheFile, 0, cfg)
+	if err != nil {
+		t.Fatalf("LoadCatalog (stale): %v", err)
+	}
+	if len(c.Releases) != 7 {
+		t.Errorf("releases = %+v, want stale catalog", c.Releases)
+	}
+}
+
+func TestFetchCatalog_Proxy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/golang.org/toolchain/@v/list":
+			w.Write([]byte(ModuleVersion("go1.21.0").Version + "\n" + ModuleVersion("go1.22.0").Version + "\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	oldURL := catalogURL
+	catalogURL = srv.URL + "/dl"
+	t.Cleanup(func() { catalogURL = oldURL })
+
+	c, err := FetchCatalog(ProxyConfig{GoProxy: srv.URL})
+	if err != nil {
+		t.Fatalf("FetchCatalog: %v", err)
+	}
+	if len(c.Releases) != 2 || c.Releases[0].Version != "go1.22.0" {
+		t.Errorf("releases = %+v, want releases from proxy", c.Releases)
+	}
+}
@@ -24,13 +24,23 @@ import (
 
 const goDownloadBaseURL = "https://dl.google.com/go"
 
-const installSuccessMarker = ".install-success"
+// Install downloads binary release of Go toolchain of the given version for the current platform
+// and unpacks it into destPath. If db is not nil, unpacked toolchain is additionally verified
+// against hash of golang.org/toolchain module recorded in checksum database.
+func Install(version, destPath string, db *SumDB, tracker progress.IOTracker) error {
+	return install(version, destPath, "", db, tracker)
+}
+
+// InstallLocked downloads binary release of Go toolchain of the given version for the current
+// platform and unpacks it into destPath, if archive has the expected SHA-256 checksum.
+// Archive with another checksum is removed and ErrChecksumMismatch is returned.
+func InstallLocked(version, destPath, wantSHA256 string, tracker progress.IOTracker) error {
+	return install(version, destPath, wantSHA256, nil, tracker)
+}
 
-func Install(version, destPath string, tracker progress.IOTracker) error {
+func install(version, destPath, wantSHA256 string, db *SumDB, tracker progress.IOTracker) error {
 	unprefixed := strings.TrimPrefix(version, "go")
-	markerPath := filepath.Join(destPath, installSuccessMarker)
-	_, err := os.Stat(markerPath)
-	if err == nil {
+	if IsInstalled(destPath) {
 		tracker.Reset(fmt.Sprintf("Version %s of Go toolchain is already installed", unprefixed))
 		return nil
 	}
@@ -72,23 +82,55 @@ func Install(version, destPath string, tracker progress.IOTracker) error {
 		}
 	}
 
-	expectedSHA, err := slurpURLToString(goURL + ".sha256")
-	if err != nil {
-		return err
+	expectedSHA := wantSHA256
+	if expectedSHA == "" {
+		expectedSHA, err = slurpURLToString(goURL + ".sha256")
+		if err != nil {
+			return err
+		}
 	}
 	if err := verifySHA256(archiveFile, expectedSHA); err != nil {
+		if wantSHA256 != "" {
+			os.Remove(archiveFile)
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, err)
+		}
 		return fmt.Errorf("verify SHA256 of %s: %w", archiveFile, err)
 	}
 	if err := unpackArchive(destPath, archiveFile, tracker); err != nil {
 		return fmt.Errorf("extract archive %s: %w", archiveFile, err)
 	}
-	if err := os.WriteFile(markerPath, nil, 0644); err != nil {
+	if db != nil {
+		if err := verifyRelease(db, version, destPath, tracker); err != nil {
+			return err
+		}
+	}
+	if err := MarkInstalled(destPath, InstallRecord{Source: goURL, SHA256: strings.TrimSpace(expectedSHA)}); err != nil {
 		return err
 	}
 	tracker.Reset(fmt.Sprintf("Successfully installed Go toolchain version %s", unprefixed))
 	return nil
 }
 
+// verifyRelease checks unpacked release against checksum database.
+// Toolchain which does not match is removed together with downloaded archive.
+func verifyRelease(db *SumDB, version, destPath string, tracker progress.IOTracker) error {
+	mod := ModuleVersion(version)
+	tracker.Reset(fmt.Sprintf("Verifying %s in %s ...", version, db.Name()))
+	err := db.VerifyDir(mod, destPath)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrSumDBDisabled):
+		tracker.Reset(fmt.Sprintf("%s is listed in GONOSUMDB, skipping verification", mod.Path))
+		return nil
+	case errors.Is(err, ErrChecksumMismatch):
+		os.RemoveAll(destPath)
+		return fmt.Errorf("verify %s: %w", version, err)
+	default:
+		return fmt.Errorf("verify %s: %w", version, err)
+	}
+}
+
 // verifySHA256 reports whether the named file has contents with
 // SHA-256 of the given wantHex value.
 func verifySHA256(file, wantHex string) error {
@@ -284,7 +326,7 @@ func downloadFromURL(dstFile, srcURL string, tracker progress.IOTracker) (err er
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return errors.New(res.Status)
+		return &statusError{url: srcURL, status: res.Status, code: res.StatusCode}
 	}
 	tracker.SetSize(res.ContentLength)
 	writer := io.MultiWriter(f, tracker.Writer())
@@ -8,6 +8,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -226,3 +227,33 @@ func writeZip(path string, files map[string]string) error {
 	}
 	return nil
 }
+
+func TestVerifyRelease(t *testing.T) {
+	dir, h := writeReleaseTree(t, "go1.22.4")
+	mod := ModuleVersion("go1.22.4")
+	db, err := NewSumDB(testSumDB(t, map[string]string{mod.Path + "@" + mod.Version: h}), t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewSumDB: %v", err)
+	}
+
+	// downloaded archive and marker are not part of toolchain
+	if err := os.WriteFile(filepath.Join(dir, "go1.22.4.linux-amd64.tar.gz"), []byte("archive"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := MarkInstalled(dir, InstallRecord{}); err != nil {
+		t.Fatalf("MarkInstalled: %v", err)
+	}
+	if err := verifyRelease(db, "go1.22.4", dir, nopTracker{}); err != nil {
+		t.Fatalf("verifyRelease: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("go1.22.5"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := verifyRelease(db, "go1.22.4", dir, nopTracker{}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("err = %v, want ErrChecksumMismatch", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("toolchain with wrong checksum must be removed, stat err = %v", err)
+	}
+}
@@ -0,0 +1,408 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/x-dvr/gm/progress"
+)
+
+const (
+	// manifestFile records files of toolchain at install time, one per line:
+	// <sha256> <mode> <size> <path>
+	manifestFile = ".gm-manifest"
+	// moduleArchive is a module zip toolchain was installed from
+	moduleArchive = ".gm-module.zip"
+)
+
+var (
+	ErrNoManifest = errors.New("toolchain has no manifest")
+	ErrNoArchive  = errors.New("no cached archive of toolchain")
+)
+
+// FileEntry describes a single file of installed toolchain.
+type FileEntry struct {
+	// Path is slash-separated path relative to GOROOT
+	Path   string
+	Size   int64
+	Mode   fs.FileMode
+	SHA256 string
+}
+
+// Manifest lists files of installed toolchain.
+type Manifest []FileEntry
+
+// Report is the result of comparing toolchain with its manifest.
+type Report struct {
+	Missing  []string
+	Modified []string
+	Extra    []string
+}
+
+// OK reports whether toolchain matches its manifest.
+func (r *Report) OK() bool {
+	return len(r.Missing) == 0 && len(r.Modified) == 0 && len(r.Extra) == 0
+}
+
+// Damaged returns files which have to be restored to match manifest.
+func (r *Report) Damaged() []string {
+	return slices.Concat(r.Missing, r.Modified)
+}
+
+// BuildManifest describes all files of toolchain in goroot.
+// Files created by gm are not included.
+func BuildManifest(goroot string) (Manifest, error) {
+	var m Manifest
+	err := walkTree(goroot, func(rel, p string, fi fs.FileInfo) error {
+		sum, err := FileSHA256(p)
+		if err != nil {
+			return err
+		}
+		m = append(m, FileEntry{Path: rel, Size: fi.Size(), Mode: fi.Mode().Perm(), SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build manifest of %s: %w", goroot, err)
+	}
+	return m, nil
+}
+
+// WriteManifest records files of toolchain in goroot for later verification.
+func WriteManifest(goroot string) error {
+	m, err := BuildManifest(goroot)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, e := range m {
+		fmt.Fprintf(&b, "%s %04o %d %s\n", e.SHA256, uint32(e.Mode), e.Size, e.Path)
+	}
+	return os.WriteFile(filepath.Join(goroot, manifestFile), []byte(b.String()), 0644)
+}
+
+// ReadManifest returns files of toolchain in goroot recorded at install time.
+func ReadManifest(goroot string) (Manifest, error) {
+	f, err := os.Open(filepath.Join(goroot, manifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoManifest
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var m Manifest
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.SplitN(sc.Text(), " ", 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("%s:%d: malformed entry", manifestFile, line)
+		}
+		mode, err := strconv.ParseUint(fields[1], 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: malformed mode: %w", manifestFile, line, err)
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: malformed size: %w", manifestFile, line, err)
+		}
+		m = append(m, FileEntry{SHA256: fields[0], Mode: fs.FileMode(mode), Size: size, Path: fields[3]})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	return m, nil
+}
+
+// VerifyTree compares toolchain in goroot with manifest recorded at install time.
+func VerifyTree(goroot string) (*Report, error) {
+	m, err := ReadManifest(goroot)
+	if err != nil {
+		return nil, err
+	}
+	want := make(map[string]FileEntry, len(m))
+	for _, e := range m {
+		want[e.Path] = e
+	}
+
+	report := &Report{}
+	seen := make(map[string]bool, len(m))
+	err = walkTree(goroot, func(rel, p string, fi fs.FileInfo) error {
+		e, ok := want[rel]
+		if !ok {
+			report.Extra = append(report.Extra, rel)
+			return nil
+		}
+		seen[rel] = true
+		if fi.Size() != e.Size || !sameMode(fi.Mode().Perm(), e.Mode) {
+			report.Modified = append(report.Modified, rel)
+			return nil
+		}
+		sum, err := FileSHA256(p)
+		if err != nil {
+			return err
+		}
+		if sum != e.SHA256 {
+			report.Modified = append(report.Modified, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify %s: %w", goroot, err)
+	}
+	for _, e := range m {
+		if !seen[e.Path] {
+			report.Missing = append(report.Missing, e.Path)
+		}
+	}
+	return report, nil
+}
+
+// CachedArchive returns path of archive toolchain in goroot was installed from.
+func CachedArchive(goroot string) (string, error) {
+	if _, err := os.Stat(filepath.Join(goroot, moduleArchive)); err == nil {
+		return filepath.Join(goroot, moduleArchive), nil
+	}
+	entries, err := os.ReadDir(goroot)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.Type().IsRegular() && isReleaseArchive(e.Name()) {
+			return filepath.Join(goroot, e.Name()), nil
+		}
+	}
+	return "", ErrNoArchive
+}
+
+// Repair restores the given files (slash-separated paths relative to goroot)
+// of toolchain from archive it was installed from. Permissions of restored
+// files are set as recorded in manifest.
+func Repair(goroot string, files []string, tracker progress.IOTracker) error {
+	m, err := ReadManifest(goroot)
+	if err != nil {
+		return err
+	}
+	archive, err := CachedArchive(goroot)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool, len(files))
+	for _, rel := range files {
+		if !validRelPath(rel) {
+			return fmt.Errorf("invalid file name %q", rel)
+		}
+		wanted[rel] = true
+		if err := os.Remove(filepath.Join(goroot, filepath.FromSlash(rel))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove damaged file: %w", err)
+		}
+	}
+
+	tracker.Reset(fmt.Sprintf("Extracting damaged files from %s ...", filepath.Base(archive)))
+	var restored map[string]bool
+	switch {
+	case filepath.Base(archive) == moduleArchive:
+		restored, err = extractModuleFiles(goroot, archive, wanted)
+		if err == nil {
+			err = FixModuleLayout(goroot)
+		}
+	case strings.HasSuffix(archive, ".zip"):
+		restored, err = extractZipFiles(goroot, archive, wanted, func(name string) (string, bool) {
+			return strings.CutPrefix(name, "go/")
+		})
+	default:
+		restored, err = extractTarGzFiles(goroot, archive, wanted)
+	}
+	if err != nil {
+		return fmt.Errorf("extract %s: %w", filepath.Base(archive), err)
+	}
+
+	var lost []string
+	for _, rel := range files {
+		if !restored[rel] {
+			lost = append(lost, rel)
+		}
+	}
+	if len(lost) > 0 {
+		return fmt.Errorf("files not found in %s: %s", filepath.Base(archive), strings.Join(lost, ", "))
+	}
+
+	for _, e := range m {
+		if wanted[e.Path] {
+			if err := os.Chmod(filepath.Join(goroot, filepath.FromSlash(e.Path)), e.Mode); err != nil {
+				return fmt.Errorf("restore permissions: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// extractModuleFiles extracts wanted files from toolchain module zip.
+// go.mod files are reported as restored if zip contains their _go.mod copy.
+func extractModuleFiles(goroot, archive string, wanted map[string]bool) (map[string]bool, error) {
+	restored, err := extractZipFiles(goroot, archive, wanted, func(name string) (string, bool) {
+		_, rest, ok := strings.Cut(name, "@")
+		if !ok {
+			return "", false
+		}
+		_, rel, ok := strings.Cut(rest, "/")
+		return rel, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	for rel := range wanted {
+		if path.Base(rel) == "go.mod" && !restored[rel] {
+			goMod := path.Join(path.Dir(rel), "_go.mod")
+			_, err := os.Stat(filepath.Join(goroot, filepath.FromSlash(goMod)))
+			restored[rel] = err == nil
+		}
+	}
+	return restored, nil
+}
+
+// extractZipFiles extracts wanted files from zip archive,
+// rel maps name of zip entry to path relative to goroot.
+func extractZipFiles(goroot, archive string, wanted map[string]bool, rel func(string) (string, bool)) (map[string]bool, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	restored := map[string]bool{}
+	for _, f := range zr.File {
+		name, ok := rel(f.Name)
+		if !ok || !wanted[name] || f.FileInfo().IsDir() {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = writeRestored(goroot, name, r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+		restored[name] = true
+	}
+	return restored, nil
+}
+
+// extractTarGzFiles extracts wanted files from binary release archive.
+func extractTarGzFiles(goroot, archive string, wanted map[string]bool) (map[string]bool, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := map[string]bool{}
+	tr := tar.NewReader(zr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(h.Name, "go/")
+		if !wanted[name] || !h.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		if err := writeRestored(goroot, name, tr); err != nil {
+			return nil, err
+		}
+		restored[name] = true
+	}
+	return restored, nil
+}
+
+func writeRestored(goroot, rel string, r io.Reader) error {
+	p := filepath.Join(goroot, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return fmt.Errorf("write %s: %w", rel, err)
+	}
+	return out.Close()
+}
+
+// walkTree calls fn for every regular file of toolchain in goroot
+// except files created by gm, in lexical order.
+func walkTree(goroot string, fn func(rel, path string, fi fs.FileInfo) error) error {
+	return filepath.WalkDir(goroot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(goroot, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if isStoreFile(rel) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(rel, p, fi)
+	})
+}
+
+// sameMode compares permissions of files. Windows has no executable bits,
+// only read-only attribute is compared there.
+func sameMode(got, want fs.FileMode) bool {
+	if runtime.GOOS == "windows" {
+		return got&0200 == want&0200
+	}
+	return got == want
+}
+
+// FileSHA256 returns hex-encoded SHA-256 checksum of file contents.
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
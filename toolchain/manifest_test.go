@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// installFromTarGz unpacks release archive with the given files the same way as Install.
+func installFromTarGz(t *testing.T, files map[string]string) string {
+	t.Helper()
+	goroot := t.TempDir()
+	archive := filepath.Join(goroot, "go1.22.4.linux-amd64.tar.gz")
+	tarFiles := make(map[string]string, len(files))
+	for name, contents := range files {
+		tarFiles["go/"+name] = contents
+	}
+	if err := writeTarGz(archive, tarFiles); err != nil {
+		t.Fatalf("write tar.gz: %v", err)
+	}
+	if err := unpackArchive(goroot, archive, nopTracker{}); err != nil {
+		t.Fatalf("unpackArchive: %v", err)
+	}
+	if err := MarkInstalled(goroot, InstallRecord{}); err != nil {
+		t.Fatalf("MarkInstalled: %v", err)
+	}
+	return goroot
+}
+
+func TestManifest(t *testing.T) {
+	goroot := installFromTarGz(t, map[string]string{
+		"VERSION":      "go1.22.4",
+		"bin/go":       "binary",
+		"src/go.mod":   "module std\n",
+		"src/fmt/a.go": "package fmt\n",
+	})
+
+	m, err := ReadManifest(goroot)
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	var names []string
+	for _, e := range m {
+		names = append(names, e.Path)
+	}
+	// archive, marker and manifest itself are not part of toolchain
+	if want := []string{"VERSION", "bin/go", "src/fmt/a.go", "src/go.mod"}; !slices.Equal(names, want) {
+		t.Errorf("manifest files = %v, want %v", names, want)
+	}
+	if m[0].Size != int64(len("go1.22.4")) || len(m[0].SHA256) != 64 {
+		t.Errorf("manifest entry = %+v", m[0])
+	}
+
+	report, err := VerifyTree(goroot)
+	if err != nil {
+		t.Fatalf("VerifyTree: %v", err)
+	}
+	if !report.OK() {
+		t.Errorf("fresh toolchain reported as damaged: %+v", report)
+	}
+}
+
+func TestVerifyTree_Damaged(t *testing.T) {
+	goroot := installFromTarGz(t, map[string]string{
+		"VERSION":      "go1.22.4",
+		"bin/go":       "binary",
+		"src/go.mod":   "module std\n",
+		"src/fmt/a.go": "package fmt\n",
+	})
+
+	write := func(rel, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(goroot, filepath.FromSlash(rel)), []byte(contents), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	write("src/go.mod", "module std\n\nrequire example.com/x v1.0.0\n")
+	// same size, different contents
+	write("VERSION", "go1.22.5")
+	write("src/fmt/extra.go", "package fmt\n")
+	if err := os.Remove(filepath.Join(goroot, "bin", "go")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	report, err := VerifyTree(goroot)
+	if err != nil {
+		t.Fatalf("VerifyTree: %v", err)
+	}
+	if !slices.Equal(report.Missing, []string{"bin/go"}) {
+		t.Errorf("Missing = %v", report.Missing)
+	}
+	if !slices.Equal(report.Modified, []string{"VERSION", "src/go.mod"}) {
+		t.Errorf("Modified = %v", report.Modified)
+	}
+	if !slices.Equal(report.Extra, []string{"src/fmt/extra.go"}) {
+		t.Errorf("Extra = %v", report.Extra)
+	}
+
+	if err := Repair(goroot, report.Damaged(), nopTracker{}); err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	report, err = VerifyTree(goroot)
+	if err != nil {
+		t.Fatalf("VerifyTree: %v", err)
+	}
+	if len(report.Damaged()) != 0 || !slices.Equal(report.Extra, []string{"src/fmt/extra.go"}) {
+		t.Errorf("after repair: %+v", report)
+	}
+}
+
+func TestVerifyTree_ModeChanged(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no executable bits on windows")
+	}
+	goroot := installFromTarGz(t, map[string]string{"VERSION": "go1.22.4", "bin/go": "binary"})
+	if err := os.Chmod(filepath.Join(goroot, "bin", "go"), 0755); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	report, err := VerifyTree(goroot)
+	if err != nil {
+		t.Fatalf("VerifyTree: %v", err)
+	}
+	if !slices.Equal(report.Modified, []string{"bin/go"}) {
+		t.Errorf("Modified = %v", report.Modified)
+	}
+}
+
+func TestRepair_ModuleArchive(t *testing.T) {
+	mod := ModuleVersion("go1.22.4")
+	archive := filepath.Join(t.TempDir(), "module.zip")
+	writeModuleZip(t, archive, mod, map[string]string{
+		"VERSION":     "go1.22.4",
+		"bin/go":      "binary",
+		"src/_go.mod": "module std\n",
+	})
+	goroot := filepath.Join(t.TempDir(), "go1.22.4")
+	if err := UnpackModule(archive, goroot, mod); err != nil {
+		t.Fatalf("UnpackModule: %v", err)
+	}
+	if err := os.Rename(archive, filepath.Join(goroot, moduleArchive)); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := MarkInstalled(goroot, InstallRecord{}); err != nil {
+		t.Fatalf("MarkInstalled: %v", err)
+	}
+
+	for _, rel := range []string{"bin/go", "src/go.mod"} {
+		p := filepath.Join(goroot, filepath.FromSlash(rel))
+		if err := os.Chmod(p, 0644); err != nil {
+			t.Fatalf("chmod: %v", err)
+		}
+		if err := os.WriteFile(p, []byte("tampered"), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	report, err := VerifyTree(goroot)
+	if err != nil {
+		t.Fatalf("VerifyTree: %v", err)
+	}
+	if err := Repair(goroot, report.Damaged(), nopTracker{}); err != nil {
+		t.Fatalf("Repair: %v", err)
+	}
+	report, err = VerifyTree(goroot)
+	if err != nil {
+		t.Fatalf("VerifyTree: %v", err)
+	}
+	if !report.OK() {
+		t.Errorf("after repair: %+v", report)
+	}
+}
+
+func TestRepair_NoArchive(t *testing.T) {
+	goroot := installFromTarGz(t, map[string]string{"VERSION": "go1.22.4"})
+	if err := os.Remove(filepath.Join(goroot, "go1.22.4.linux-amd64.tar.gz")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := Repair(goroot, []string{"VERSION"}, nopTracker{}); !errors.Is(err, ErrNoArchive) {
+		t.Errorf("err = %v, want ErrNoArchive", err)
+	}
+}
+
+func TestReadManifest_Missing(t *testing.T) {
+	if _, err := ReadManifest(t.TempDir()); !errors.Is(err, ErrNoManifest) {
+		t.Errorf("err = %v, want ErrNoManifest", err)
+	}
+}
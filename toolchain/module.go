@@ -0,0 +1,214 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+	modzip "golang.org/x/mod/zip"
+)
+
+// Go toolchains are published as versions of golang.org/toolchain module:
+// v0.0.1-<version>.<goos>-<goarch>
+const (
+	ToolchainModule        = "golang.org/toolchain"
+	toolchainModuleVersion = "v0.0.1"
+)
+
+// moduleExcludedDirs are top level directories of release tree
+// left out of toolchain module zip by cmd/distpack.
+var moduleExcludedDirs = []string{"api", "doc", "misc", "test"}
+
+// ModuleVersion returns version of toolchain module holding
+// the given version of Go for the current platform.
+func ModuleVersion(version string) module.Version {
+	return module.Version{
+		Path:    ToolchainModule,
+		Version: fmt.Sprintf("%s-%s.%s-%s", toolchainModuleVersion, version, runtime.GOOS, runtime.GOARCH),
+	}
+}
+
+// ParseModuleVersion extracts Go version from version of toolchain module.
+// It reports false if module version is not for the current platform.
+func ParseModuleVersion(modVersion string) (string, bool) {
+	rest, ok := strings.CutPrefix(modVersion, toolchainModuleVersion+"-")
+	if !ok {
+		return "", false
+	}
+	version, ok := strings.CutSuffix(rest, "."+runtime.GOOS+"-"+runtime.GOARCH)
+	if !ok || !strings.HasPrefix(version, "go") {
+		return "", false
+	}
+	return version, true
+}
+
+// HashModuleDir returns h1 hash of toolchain module mod installed into dir.
+// Files created by gm or by the go command after extraction are not included,
+// go.mod files of Go release trees are hashed as _go.mod, same as in module zip.
+func HashModuleDir(dir string, mod module.Version) (string, error) {
+	files, err := moduleFiles(dir, mod)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	return dirhash.Hash1(names, func(name string) (io.ReadCloser, error) {
+		return os.Open(files[name])
+	})
+}
+
+// HashModuleZip returns h1 hash of toolchain module zip.
+func HashModuleZip(zipFile string) (string, error) {
+	return dirhash.HashZip(zipFile, dirhash.Hash1)
+}
+
+// UnpackModule extracts toolchain module zip into destPath
+// and restores layout of Go installation.
+func UnpackModule(zipFile, destPath string, mod module.Version) error {
+	if err := modzip.Unzip(destPath, mod, zipFile); err != nil {
+		return fmt.Errorf("extract module %s: %w", mod, err)
+	}
+	return FixModuleLayout(destPath)
+}
+
+// FixModuleLayout turns toolchain module extracted into goroot into a usable
+// Go installation, the same way as the go command does after download:
+// module zips lose executable bits and have all go.mod files renamed to _go.mod.
+func FixModuleLayout(goroot string) error {
+	if _, err := os.Stat(filepath.Join(goroot, "src", "_go.mod")); err != nil {
+		// not a module layout
+		return nil
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := allowExec(filepath.Join(goroot, "pkg", "tool"), ""); err != nil {
+			return err
+		}
+		if err := allowExec(filepath.Join(goroot, "lib"), "go_?*_?*_exec"); err != nil {
+			return err
+		}
+		if err := allowExec(filepath.Join(goroot, "bin"), ""); err != nil {
+			return err
+		}
+	}
+
+	return filepath.WalkDir(goroot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "_go.mod" {
+			return nil
+		}
+		goMod := filepath.Join(filepath.Dir(p), "go.mod")
+		if _, err := os.Stat(goMod); err == nil {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(goMod, data, 0644)
+	})
+}
+
+// CreateModuleZip writes toolchain installed in goroot as zip of toolchain module mod.
+// It reverses FixModuleLayout, so zip has the same hash as published module
+// if toolchain files are unchanged.
+func CreateModuleZip(w io.Writer, goroot string, mod module.Version) error {
+	files, err := moduleFiles(goroot, mod)
+	if err != nil {
+		return fmt.Errorf("create zip of %s: %w", mod, err)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		if err := addZipFile(zw, name, files[name]); err != nil {
+			return fmt.Errorf("create zip of %s: %w", mod, err)
+		}
+	}
+	return zw.Close()
+}
+
+func addZipFile(zw *zip.Writer, name, file string) error {
+	out, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(out, f)
+	return err
+}
+
+// moduleFiles maps names of files in zip of toolchain module mod to files
+// of toolchain installed in goroot. Files created by gm and directories
+// excluded by cmd/distpack are omitted. Since modules can't contain nested
+// modules, go.mod files are stored as _go.mod, copies restored by
+// FixModuleLayout are omitted.
+func moduleFiles(goroot string, mod module.Version) (map[string]string, error) {
+	prefix := mod.Path + "@" + mod.Version + "/"
+	files := map[string]string{}
+	err := walkTree(goroot, func(rel, p string, _ fs.FileInfo) error {
+		if top, _, inDir := strings.Cut(rel, "/"); inDir && slices.Contains(moduleExcludedDirs, top) {
+			return nil
+		}
+		if path.Base(rel) == "go.mod" {
+			if _, err := os.Stat(filepath.Join(filepath.Dir(p), "_go.mod")); err == nil {
+				return nil
+			}
+			rel = path.Join(path.Dir(rel), "_go.mod")
+		}
+		files[prefix+rel] = p
+		return nil
+	})
+	return files, err
+}
+
+// allowExec sets executable bits on all files in dir matching pattern,
+// or on all files if pattern is empty.
+func allowExec(dir, pattern string) error {
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if pattern != "" {
+			if matched, _ := filepath.Match(pattern, d.Name()); !matched {
+				return nil
+			}
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return os.Chmod(p, fi.Mode()&0777|0111)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
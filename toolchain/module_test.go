@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+	modzip "golang.org/x/mod/zip"
+)
+
+// writeModuleZip creates zip of toolchain module with the given files (path -> contents).
+func writeModuleZip(t *testing.T, path string, mod module.Version, files map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+	defer f.Close()
+	if err := modzip.CreateFromDir(f, mod, dir); err != nil {
+		t.Fatalf("CreateFromDir: %v", err)
+	}
+}
+
+func TestModuleVersion(t *testing.T) {
+	mod := ModuleVersion("go1.22.4")
+	if mod.Path != ToolchainModule {
+		t.Errorf("Path = %q, want %q", mod.Path, ToolchainModule)
+	}
+	want := "v0.0.1-go1.22.4." + runtime.GOOS + "-" + runtime.GOARCH
+	if mod.Version != want {
+		t.Errorf("Version = %q, want %q", mod.Version, want)
+	}
+
+	got, ok := ParseModuleVersion(mod.Version)
+	if !ok || got != "go1.22.4" {
+		t.Errorf("ParseModuleVersion(%q) = %q, %v", mod.Version, got, ok)
+	}
+	if _, ok := ParseModuleVersion("v0.0.1-go1.22.4.plan9-mips"); ok {
+		t.Error("ParseModuleVersion accepted version for another platform")
+	}
+	if _, ok := ParseModuleVersion("v1.0.0"); ok {
+		t.Error("ParseModuleVersion accepted unrelated version")
+	}
+}
+
+func TestUnpackModule(t *testing.T) {
+	mod := ModuleVersion("go1.22.4")
+	archive := filepath.Join(t.TempDir(), mod.Version+".zip")
+	writeModuleZip(t, archive, mod, map[string]string{
+		"VERSION":            "go1.22.4",
+		"bin/go":             "binary",
+		"pkg/tool/x/compile": "binary",
+		"src/_go.mod":        "module std\n",
+		"src/cmd/_go.mod":    "module cmd\n",
+	})
+
+	dest := filepath.Join(t.TempDir(), "go1.22.4")
+	if err := UnpackModule(archive, dest, mod); err != nil {
+		t.Fatalf("UnpackModule: %v", err)
+	}
+
+	for _, name := range []string{"src/go.mod", "src/cmd/go.mod"} {
+		if _, err := os.Stat(filepath.Join(dest, filepath.FromSlash(name))); err != nil {
+			t.Errorf("%s must be restored: %v", name, err)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		for _, name := range []string{"bin/go", "pkg/tool/x/compile"} {
+			fi, err := os.Stat(filepath.Join(dest, filepath.FromSlash(name)))
+			if err != nil {
+				t.Fatalf("stat %s: %v", name, err)
+			}
+			if fi.Mode()&0111 == 0 {
+				t.Errorf("%s must be executable, mode %v", name, fi.Mode())
+			}
+		}
+	}
+
+	// hash of extracted tree must match hash of zip even after go.mod files were restored
+	zipHash, err := HashModuleZip(archive)
+	if err != nil {
+		t.Fatalf("HashModuleZip: %v", err)
+	}
+	dirHash, err := HashModuleDir(dest, mod)
+	if err != nil {
+		t.Fatalf("HashModuleDir: %v", err)
+	}
+	if zipHash != dirHash {
+		t.Errorf("HashModuleDir = %s, want %s", dirHash, zipHash)
+	}
+}
+
+func TestFixModuleLayout_RegularTree(t *testing.T) {
+	goroot := t.TempDir()
+	bin := filepath.Join(goroot, "bin", "go")
+	if err := os.MkdirAll(filepath.Dir(bin), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(bin, []byte("binary"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if err := FixModuleLayout(goroot); err != nil {
+		t.Fatalf("FixModuleLayout: %v", err)
+	}
+	fi, err := os.Stat(bin)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if fi.Mode().Perm() != 0644 {
+		t.Errorf("mode of regular tree must not change, got %v", fi.Mode())
+	}
+}
+
+func TestCreateModuleZip(t *testing.T) {
+	mod := ModuleVersion("go1.22.4")
+	files := map[string]string{
+		"VERSION":         "go1.22.4",
+		"bin/go":          "binary",
+		"src/_go.mod":     "module std\n",
+		"src/cmd/_go.mod": "module cmd\n",
+	}
+	archive := filepath.Join(t.TempDir(), mod.Version+".zip")
+	writeModuleZip(t, archive, mod, files)
+	want, err := HashModuleZip(archive)
+	if err != nil {
+		t.Fatalf("HashModuleZip: %v", err)
+	}
+
+	// tree installed from module zip
+	moduleTree := filepath.Join(t.TempDir(), "go1.22.4")
+	if err := UnpackModule(archive, moduleTree, mod); err != nil {
+		t.Fatalf("UnpackModule: %v", err)
+	}
+	// tree installed from binary release archive
+	releaseTree := t.TempDir()
+	for name, contents := range files {
+		name = strings.ReplaceAll(name, "_go.mod", "go.mod")
+		p := filepath.Join(releaseTree, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(releaseTree, "go1.22.4.linux-amd64.tar.gz"), []byte("archive"), 0644); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+
+	for _, goroot := range []string{moduleTree, releaseTree} {
+		if err := MarkInstalled(goroot, InstallRecord{}); err != nil {
+			t.Fatalf("MarkInstalled: %v", err)
+		}
+		out := filepath.Join(t.TempDir(), "out.zip")
+		f, err := os.Create(out)
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if err := CreateModuleZip(f, goroot, mod); err != nil {
+			t.Fatalf("CreateModuleZip(%s): %v", goroot, err)
+		}
+		f.Close()
+
+		got, err := HashModuleZip(out)
+		if err != nil {
+			t.Fatalf("HashModuleZip: %v", err)
+		}
+		if got != want {
+			t.Errorf("hash of zip created from %s = %s, want %s", goroot, got, want)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/module"
+
+	"github.com/x-dvr/gm/progress"
+)
+
+const defaultGoProxy = "https://proxy.golang.org,direct"
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrProxyDisabled    = errors.New("module proxy is disabled by GOPROXY=off")
+	ErrNoProxy          = errors.New("no module proxy configured in GOPROXY")
+)
+
+var httpClient = &http.Client{
+	Transport: &userAgentTransport{&http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}},
+}
+
+// DownloadSource selects where toolchain archives are downloaded from.
+type DownloadSource string
+
+const (
+	// SourceDL downloads binary releases from Go download server.
+	SourceDL DownloadSource = "dl"
+	// SourceProxy downloads golang.org/toolchain module from GOPROXY.
+	SourceProxy DownloadSource = "proxy"
+	// SourceAuto downloads from Go download server, falling back to GOPROXY.
+	SourceAuto DownloadSource = "auto"
+)
+
+// ParseDownloadSource validates download source given on command line.
+func ParseDownloadSource(s string) (DownloadSource, error) {
+	switch src := DownloadSource(s); src {
+	case SourceDL, SourceProxy, SourceAuto:
+		return src, nil
+	default:
+		return "", fmt.Errorf("unknown download source %q (supported: dl, proxy, auto)", s)
+	}
+}
+
+// ProxyConfig describes module proxies and checksum database
+// used to download toolchains as golang.org/toolchain module.
+type ProxyConfig struct {
+	// GoProxy is a list of proxies in GOPROXY format
+	GoProxy string
+	// GoSumDB is checksum database in GOSUMDB format
+	GoSumDB string
+	// NoSumDB is a list of module path patterns not checked in checksum database
+	NoSumDB string
+	// SumDBCache is a directory for cached checksum database tree heads and tiles
+	SumDBCache string
+}
+
+// ProxyConfigFromEnv reads proxy configuration from the go command environment variables.
+func ProxyConfigFromEnv(sumdbCache string) ProxyConfig {
+	cfg := ProxyConfig{
+		GoProxy:    os.Getenv("GOPROXY"),
+		GoSumDB:    os.Getenv("GOSUMDB"),
+		SumDBCache: sumdbCache,
+	}
+	if cfg.GoProxy == "" {
+		cfg.GoProxy = defaultGoProxy
+	}
+
+	var noSumDB []string
+	for _, env := range []string{"GONOSUMDB", "GOPRIVATE"} {
+		if v := os.Getenv(env); v != "" {
+			noSumDB = append(noSumDB, v)
+		}
+	}
+	cfg.NoSumDB = strings.Join(noSumDB, ",")
+	if hasFlag(os.Getenv("GOFLAGS"), "-insecure") {
+		cfg.GoSumDB = "off"
+	}
+	return cfg
+}
+
+// SumDB returns client of checksum database described by configuration.
+// It returns ErrSumDBDisabled if checksum database is turned off.
+func (cfg ProxyConfig) SumDB() (*SumDB, error) {
+	// checksum database may be served by proxies
+	proxies, _ := parseGoProxy(cfg.GoProxy)
+	db, err := NewSumDB(cfg.GoSumDB, cfg.SumDBCache, proxyURLs(proxies))
+	if err != nil {
+		if errors.Is(err, ErrSumDBDisabled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("connect to checksum database: %w", err)
+	}
+	db.SetNoSumDB(cfg.NoSumDB)
+	return db, nil
+}
+
+// proxyEntry is a single module proxy from GOPROXY list.
+type proxyEntry struct {
+	url string
+	// fallback is set when next proxy should be tried on any error,
+	// otherwise only on "not found" responses.
+	fallback bool
+}
+
+// parseGoProxy parses GOPROXY list. Entries "direct" are skipped,
+// since toolchain module is available only from proxies.
+func parseGoProxy(goproxy string) ([]proxyEntry, error) {
+	var entries []proxyEntry
+	for goproxy != "" {
+		var entry string
+		i := strings.IndexAny(goproxy, ",|")
+		fallback := false
+		if i < 0 {
+			entry, goproxy = goproxy, ""
+		} else {
+			entry, fallback, goproxy = goproxy[:i], goproxy[i] == '|', goproxy[i+1:]
+		}
+		entry = strings.TrimSpace(entry)
+		switch entry {
+		case "":
+			continue
+		case "direct", "noproxy":
+			continue
+		case "off":
+			if len(entries) == 0 {
+				return nil, ErrProxyDisabled
+			}
+			return entries, nil
+		}
+		entries = append(entries, proxyEntry{url: strings.TrimSuffix(entry, "/"), fallback: fallback})
+	}
+	if len(entries) == 0 {
+		return nil, ErrNoProxy
+	}
+	return entries, nil
+}
+
+// InstallFromProxy downloads toolchain of the given version for the current platform
+// as golang.org/toolchain module from GOPROXY, verifies it against checksum database
+// and unpacks into destPath.
+func InstallFromProxy(version, destPath string, cfg ProxyConfig, tracker progress.IOTracker) error {
+	unprefixed := strings.TrimPrefix(version, "go")
+	if IsInstalled(destPath) {
+		tracker.Reset(fmt.Sprintf("Version %s of Go toolchain is already installed", unprefixed))
+		return nil
+	}
+
+	proxies, err := parseGoProxy(cfg.GoProxy)
+	if err != nil {
+		return err
+	}
+	mod := ModuleVersion(version)
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("create versions directory: %w", err)
+	}
+	zipFile := destPath + ".zip"
+	defer os.Remove(zipFile)
+	source, err := downloadModule(proxies, mod, zipFile, tracker)
+	if err != nil {
+		return err
+	}
+	sum, err := FileSHA256(zipFile)
+	if err != nil {
+		return fmt.Errorf("hash module zip: %w", err)
+	}
+
+	db, err := cfg.SumDB()
+	switch {
+	case errors.Is(err, ErrSumDBDisabled):
+		tracker.Reset("Checksum database is disabled, skipping verification")
+	case err != nil:
+		return err
+	default:
+		tracker.Reset(fmt.Sprintf("Verifying %s in %s ...", mod.Version, db.Name()))
+		err := db.Verify(mod, zipFile)
+		if errors.Is(err, ErrSumDBDisabled) {
+			tracker.Reset(fmt.Sprintf("%s is listed in GONOSUMDB, skipping verification", mod.Path))
+		} else if err != nil {
+			return fmt.Errorf("verify %s: %w", mod, err)
+		}
+	}
+
+	tmpPath := destPath + ".tmp"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("cleanup previous installation: %w", err)
+	}
+	tracker.Reset(fmt.Sprintf("Extracting %s ...", filepath.Base(zipFile)))
+	if err := UnpackModule(zipFile, tmpPath, mod); err != nil {
+		os.RemoveAll(tmpPath)
+		return err
+	}
+	// keep verified zip to repair toolchain later
+	if err := os.Rename(zipFile, filepath.Join(tmpPath, moduleArchive)); err != nil {
+		return fmt.Errorf("keep module zip: %w", err)
+	}
+	if err := MarkInstalled(tmpPath, InstallRecord{Source: source, SHA256: sum}); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(destPath); err != nil {
+		return fmt.Errorf("cleanup previous installation: %w", err)
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("move toolchain into %s: %w", destPath, err)
+	}
+	tracker.Reset(fmt.Sprintf("Successfully installed Go toolchain version %s", unprefixed))
+	return nil
+}
+
+// downloadModule downloads module zip from the first proxy serving it
+// and returns URL it was downloaded from.
+func downloadModule(proxies []proxyEntry, mod module.Version, dst string, tracker progress.IOTracker) (string, error) {
+	var errs []error
+	for _, p := range proxies {
+		target := fmt.Sprintf("%s/%s/@v/%s.zip", p.url, mod.Path, mod.Version)
+		tracker.Reset(fmt.Sprintf("Downloading %s ...", target))
+		err := downloadFromURL(dst, target, tracker)
+		if err == nil {
+			return target, nil
+		}
+		errs = append(errs, fmt.Errorf("download %s: %w", target, err))
+
+		var se *statusError
+		notFound := errors.As(err, &se) && (se.code == http.StatusNotFound || se.code == http.StatusGone)
+		if !p.fallback && !notFound {
+			break
+		}
+	}
+	return "", errors.Join(errs...)
+}
+
+func proxyURLs(proxies []proxyEntry) []string {
+	urls := make([]string, 0, len(proxies))
+	for _, p := range proxies {
+		urls = append(urls, p.url)
+	}
+	return urls
+}
+
+// hasFlag reports whether GOFLAGS list contains the given flag.
+func hasFlag(goflags, flag string) bool {
+	for _, f := range strings.Fields(goflags) {
+		if f == flag || strings.HasPrefix(f, flag+"=") && strings.TrimPrefix(f, flag+"=") != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+// statusError reports unsuccessful HTTP response.
+type statusError struct {
+	url    string
+	status string
+	code   int
+}
+
+func (e *statusError) Error() string {
+	return e.status
+}
@@ -0,0 +1,200 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+)
+
+func TestParseGoProxy(t *testing.T) {
+	tests := []struct {
+		goproxy string
+		want    []proxyEntry
+		err     error
+	}{
+		{
+			goproxy: "https://proxy.golang.org,direct",
+			want:    []proxyEntry{{url: "https://proxy.golang.org"}},
+		},
+		{
+			goproxy: "https://athens.corp/|https://proxy.golang.org,off",
+			want:    []proxyEntry{{url: "https://athens.corp", fallback: true}, {url: "https://proxy.golang.org"}},
+		},
+		{goproxy: "off", err: ErrProxyDisabled},
+		{goproxy: "direct", err: ErrNoProxy},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGoProxy(tt.goproxy)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("parseGoProxy(%q): err = %v, want %v", tt.goproxy, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseGoProxy(%q): %v", tt.goproxy, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseGoProxy(%q) = %+v, want %+v", tt.goproxy, got, tt.want)
+		}
+	}
+}
+
+func TestHasFlag(t *testing.T) {
+	if !hasFlag("-mod=mod -insecure", "-insecure") {
+		t.Error("flag not found")
+	}
+	if hasFlag("-insecure=false", "-insecure") {
+		t.Error("disabled flag reported as set")
+	}
+	if hasFlag("", "-insecure") {
+		t.Error("flag found in empty list")
+	}
+}
+
+// testProxy starts module proxy serving module zips from dir.
+func testProxy(t *testing.T, dir string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// writeProxyModule creates toolchain module zip of the given version in proxy directory
+// and returns its hash.
+func writeProxyModule(t *testing.T, proxyDir, version string) (module.Version, string) {
+	t.Helper()
+	mod := ModuleVersion(version)
+	dir := filepath.Join(proxyDir, filepath.FromSlash(mod.Path), "@v")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	zipPath := filepath.Join(dir, mod.Version+".zip")
+	writeModuleZip(t, zipPath, mod, map[string]string{
+		"VERSION":     version,
+		"bin/go":      "binary",
+		"src/_go.mod": "module std\n",
+	})
+	h, err := HashModuleZip(zipPath)
+	if err != nil {
+		t.Fatalf("HashModuleZip: %v", err)
+	}
+	return mod, h
+}
+
+func TestInstallFromProxy(t *testing.T) {
+	empty := testProxy(t, t.TempDir())
+	proxyDir := t.TempDir()
+	mod, h := writeProxyModule(t, proxyDir, "go1.22.4")
+	proxy := testProxy(t, proxyDir)
+
+	cfg := ProxyConfig{
+		GoProxy:    empty + "," + proxy + ",direct",
+		GoSumDB:    testSumDB(t, map[string]string{mod.Path + "@" + mod.Version: h}),
+		SumDBCache: t.TempDir(),
+	}
+	dest := filepath.Join(t.TempDir(), "go1.22.4")
+	if err := InstallFromProxy("go1.22.4", dest, cfg, nopTracker{}); err != nil {
+		t.Fatalf("InstallFromProxy: %v", err)
+	}
+
+	if !IsInstalled(dest) {
+		t.Error("toolchain is not marked as installed")
+	}
+	if got, err := ReadVersion(dest); err != nil || got != "go1.22.4" {
+		t.Errorf("ReadVersion = %q, %v, want go1.22.4", got, err)
+	}
+	if _, err := os.Stat(dest + ".zip"); !os.IsNotExist(err) {
+		t.Errorf("downloaded zip must be moved, stat err = %v", err)
+	}
+	if archive, err := CachedArchive(dest); err != nil || filepath.Base(archive) != moduleArchive {
+		t.Errorf("CachedArchive = %q, %v, want module zip kept for repair", archive, err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.SumDBCache, "config", "sumdb.test", "latest")); err != nil {
+		t.Errorf("verified tree head must be cached: %v", err)
+	}
+}
+
+func TestInstallFromProxy_ChecksumMismatch(t *testing.T) {
+	proxyDir := t.TempDir()
+	mod, _ := writeProxyModule(t, proxyDir, "go1.22.4")
+
+	cfg := ProxyConfig{
+		GoProxy:    testProxy(t, proxyDir),
+		GoSumDB:    testSumDB(t, map[string]string{mod.Path + "@" + mod.Version: "h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}),
+		SumDBCache: t.TempDir(),
+	}
+	dest := filepath.Join(t.TempDir(), "go1.22.4")
+	err := InstallFromProxy("go1.22.4", dest, cfg, nopTracker{})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("err = %v, want ErrChecksumMismatch", err)
+	}
+	if IsInstalled(dest) {
+		t.Error("toolchain with wrong checksum must not be installed")
+	}
+}
+
+func TestInstallFromProxy_NoSumDB(t *testing.T) {
+	proxyDir := t.TempDir()
+	writeProxyModule(t, proxyDir, "go1.22.4")
+
+	for _, cfg := range []ProxyConfig{
+		{GoSumDB: "off"},
+		{GoSumDB: testSumDB(t, nil), NoSumDB: "golang.org/toolchain"},
+	} {
+		cfg.GoProxy = testProxy(t, proxyDir)
+		cfg.SumDBCache = t.TempDir()
+		dest := filepath.Join(t.TempDir(), "go1.22.4")
+		if err := InstallFromProxy("go1.22.4", dest, cfg, nopTracker{}); err != nil {
+			t.Errorf("InstallFromProxy(%+v): %v", cfg, err)
+		}
+	}
+}
+
+func TestInstallFromProxy_NotFound(t *testing.T) {
+	cfg := ProxyConfig{
+		GoProxy:    testProxy(t, t.TempDir()),
+		GoSumDB:    "off",
+		SumDBCache: t.TempDir(),
+	}
+	err := InstallFromProxy("go1.22.4", filepath.Join(t.TempDir(), "go1.22.4"), cfg, nopTracker{})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("err = %v, want not found error", err)
+	}
+}
+
+func TestProxyConfigFromEnv(t *testing.T) {
+	t.Setenv("GOPROXY", "")
+	t.Setenv("GOSUMDB", "")
+	t.Setenv("GONOSUMDB", "corp.example")
+	t.Setenv("GOPRIVATE", "git.example")
+	t.Setenv("GOFLAGS", "")
+
+	cfg := ProxyConfigFromEnv("cache")
+	if cfg.GoProxy != defaultGoProxy {
+		t.Errorf("GoProxy = %q, want default", cfg.GoProxy)
+	}
+	if cfg.NoSumDB != "corp.example,git.example" {
+		t.Errorf("NoSumDB = %q", cfg.NoSumDB)
+	}
+	if cfg.SumDBCache != "cache" {
+		t.Errorf("SumDBCache = %q", cfg.SumDBCache)
+	}
+
+	t.Setenv("GOFLAGS", "-insecure")
+	if cfg := ProxyConfigFromEnv("cache"); cfg.GoSumDB != "off" {
+		t.Errorf("GoSumDB = %q, want off with -insecure flag", cfg.GoSumDB)
+	}
+}
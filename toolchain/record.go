@@ -0,0 +1,186 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+const (
+	// recordFile holds InstallRecord of toolchain
+	recordFile = ".gm-install.json"
+	// installSuccessMarker is an empty file marking toolchains installed by older versions of gm
+	installSuccessMarker = ".install-success"
+)
+
+var ErrNoRecord = errors.New("toolchain has no install record")
+
+// InstallRecord describes installation of toolchain.
+type InstallRecord struct {
+	// Version as recorded in VERSION file of toolchain, e.g. go1.22.0
+	Version string `json:"version"`
+	GOOS    string `json:"goos"`
+	GOARCH  string `json:"goarch"`
+	// Source is URL, path or repository toolchain was installed from
+	Source string `json:"source,omitempty"`
+	// SHA256 is a checksum of archive toolchain was installed from
+	SHA256      string    `json:"sha256,omitempty"`
+	GMVersion   string    `json:"gm_version,omitempty"`
+	InstalledAt time.Time `json:"installed_at"`
+	LastUsedAt  time.Time `json:"last_used_at,omitzero"`
+}
+
+// MarkInstalled records that toolchain at destPath is completely installed,
+// along with manifest of its files used to verify it later.
+// Fields of rec describing the toolchain itself are filled in automatically.
+func MarkInstalled(destPath string, rec InstallRecord) error {
+	if err := WriteManifest(destPath); err != nil {
+		return err
+	}
+	version, err := treeVersion(destPath)
+	if err != nil {
+		return fmt.Errorf("read version: %w", err)
+	}
+	rec.Version = version
+	if rec.GOOS == "" {
+		rec.GOOS, rec.GOARCH = runtime.GOOS, runtime.GOARCH
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		rec.GMVersion = info.Main.Version
+	}
+	rec.InstalledAt = time.Now().UTC()
+	return writeRecord(destPath, &rec)
+}
+
+// IsInstalled reports whether toolchain at destPath is completely installed.
+func IsInstalled(destPath string) bool {
+	for _, name := range []string{recordFile, installSuccessMarker} {
+		if _, err := os.Stat(filepath.Join(destPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearInstalled removes install record and marker from toolchain at destPath,
+// so a tree reused for rebuild counts as installed only after MarkInstalled.
+func ClearInstalled(destPath string) error {
+	for _, name := range []string{recordFile, installSuccessMarker} {
+		if err := os.Remove(filepath.Join(destPath, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("clear install record: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadRecord returns install record of toolchain at destPath. For toolchains
+// installed by older versions of gm, record is restored from the tree.
+func ReadRecord(destPath string) (*InstallRecord, error) {
+	data, err := os.ReadFile(filepath.Join(destPath, recordFile))
+	if err == nil {
+		var rec InstallRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("parse install record: %w", err)
+		}
+		return &rec, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	fi, err := os.Stat(filepath.Join(destPath, installSuccessMarker))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoRecord
+		}
+		return nil, err
+	}
+	version, err := treeVersion(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("read version: %w", err)
+	}
+	return &InstallRecord{
+		Version:     version,
+		GOOS:        runtime.GOOS,
+		GOARCH:      runtime.GOARCH,
+		InstalledAt: fi.ModTime().UTC(),
+	}, nil
+}
+
+// TouchLastUsed records that toolchain at destPath was used now.
+func TouchLastUsed(destPath string) error {
+	rec, err := ReadRecord(destPath)
+	if err != nil {
+		return err
+	}
+	rec.LastUsedAt = time.Now().UTC()
+	return writeRecord(destPath, rec)
+}
+
+// TouchLastUsedEvery records that toolchain at destPath was used now, unless
+// use within interval is already recorded, so frequent callers rarely write.
+func TouchLastUsedEvery(destPath string, interval time.Duration) error {
+	rec, err := ReadRecord(destPath)
+	if err != nil {
+		return err
+	}
+	if time.Since(rec.LastUsedAt) < interval {
+		return nil
+	}
+	rec.LastUsedAt = time.Now().UTC()
+	return writeRecord(destPath, rec)
+}
+
+// InstallTime returns time when toolchain at destPath was installed.
+// For toolchains installed outside of gm modification time of VERSION file is used.
+func InstallTime(destPath string) time.Time {
+	if rec, err := ReadRecord(destPath); err == nil {
+		return rec.InstalledAt
+	}
+	if fi, err := os.Stat(filepath.Join(destPath, "VERSION")); err == nil {
+		return fi.ModTime()
+	}
+	return time.Time{}
+}
+
+func writeRecord(destPath string, rec *InstallRecord) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(destPath, recordFile), append(data, '\n')); err != nil {
+		return fmt.Errorf("write install record: %w", err)
+	}
+	// record replaces marker of older versions
+	if err := os.Remove(filepath.Join(destPath, installSuccessMarker)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// treeVersion returns version of toolchain in goroot. Toolchains built
+// from git repository have no VERSION file, version reported by
+// the go command is cached by make script in VERSION.cache instead.
+func treeVersion(goroot string) (string, error) {
+	version, err := ReadVersion(goroot)
+	if err != nil || version != "" {
+		return version, err
+	}
+	data, err := os.ReadFile(filepath.Join(goroot, "VERSION.cache"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
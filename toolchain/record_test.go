@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestMarkInstalled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("go1.22.4\ntime 2024-06-04T00:00:00Z\n"), 0644); err != nil {
+		t.Fatalf("write VERSION: %v", err)
+	}
+	if IsInstalled(dir) {
+		t.Fatal("IsInstalled = true before MarkInstalled")
+	}
+	if _, err := ReadRecord(dir); !errors.Is(err, ErrNoRecord) {
+		t.Errorf("ReadRecord before MarkInstalled: err = %v, want ErrNoRecord", err)
+	}
+
+	before := time.Now().Add(-time.Second)
+	rec := InstallRecord{Source: "https://dl.google.com/go/go1.22.4.linux-amd64.tar.gz", SHA256: "abc"}
+	if err := MarkInstalled(dir, rec); err != nil {
+		t.Fatalf("MarkInstalled: %v", err)
+	}
+	if !IsInstalled(dir) {
+		t.Error("IsInstalled = false after MarkInstalled")
+	}
+
+	got, err := ReadRecord(dir)
+	if err != nil {
+		t.Fatalf("ReadRecord: %v", err)
+	}
+	if got.Version != "go1.22.4" || got.Source != rec.Source || got.SHA256 != "abc" {
+		t.Errorf("record = %+v", got)
+	}
+	if got.GOOS != runtime.GOOS || got.GOARCH != runtime.GOARCH {
+		t.Errorf("platform = %s/%s", got.GOOS, got.GOARCH)
+	}
+	if got.InstalledAt.Before(before) || !got.LastUsedAt.IsZero() {
+		t.Errorf("InstalledAt = %v, LastUsedAt = %v", got.InstalledAt, got.LastUsedAt)
+	}
+	if !InstallTime(dir).Equal(got.InstalledAt) {
+		t.Errorf("InstallTime = %v, want %v", InstallTime(dir), got.InstalledAt)
+	}
+
+	if err := TouchLastUsed(dir); err != nil {
+		t.Fatalf("TouchLastUsed: %v", err)
+	}
+	got, err = ReadRecord(dir)
+	if err != nil {
+		t.Fatalf("ReadRecord: %v", err)
+	}
+	if got.LastUsedAt.Before(got.InstalledAt) {
+		t.Errorf("LastUsedAt = %v, want after install", got.LastUsedAt)
+	}
+}
+
+func TestReadRecord_Legacy(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, installSuccessMarker)
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		t.Fatalf("write marker: %v", err)
+	}
+	// development tree built from git repository
+	if err := os.WriteFile(filepath.Join(dir, "VERSION.cache"), []byte("devel go1.24-abcdef\n"), 0644); err != nil {
+		t.Fatalf("write VERSION.cache: %v", err)
+	}
+	installed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(marker, installed, installed); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	if !IsInstalled(dir) {
+		t.Error("toolchain with marker of older version must be installed")
+	}
+	rec, err := ReadRecord(dir)
+	if err != nil {
+		t.Fatalf("ReadRecord: %v", err)
+	}
+	if rec.Version != "devel go1.24-abcdef" || !rec.InstalledAt.Equal(installed) {
+		t.Errorf("record = %+v", rec)
+	}
+
+	// record is written on first update
+	if err := TouchLastUsed(dir); err != nil {
+		t.Fatalf("TouchLastUsed: %v", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("marker must be replaced by record, stat err = %v", err)
+	}
+	rec, err = ReadRecord(dir)
+	if err != nil || !rec.InstalledAt.Equal(installed) {
+		t.Errorf("record = %+v, %v", rec, err)
+	}
+}
+
+func TestClearInstalled(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{recordFile, installSuccessMarker} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ClearInstalled(dir); err != nil {
+		t.Fatalf("ClearInstalled: %v", err)
+	}
+	if IsInstalled(dir) {
+		t.Error("tree must not be installed after ClearInstalled")
+	}
+	if err := ClearInstalled(dir); err != nil {
+		t.Errorf("ClearInstalled of cleared tree: %v", err)
+	}
+}
+
+func TestTouchLastUsedEvery(t *testing.T) {
+	dir := t.TempDir()
+	used := time.Now().Add(-10 * time.Minute).UTC().Truncate(time.Second)
+	if err := writeRecord(dir, &InstallRecord{Version: "go1.22.4", LastUsedAt: used}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := TouchLastUsedEvery(dir, time.Hour); err != nil {
+		t.Fatalf("TouchLastUsedEvery: %v", err)
+	}
+	if rec, _ := ReadRecord(dir); !rec.LastUsedAt.Equal(used) {
+		t.Errorf("LastUsedAt = %v, want %v kept within interval", rec.LastUsedAt, used)
+	}
+	if err := TouchLastUsedEvery(dir, time.Minute); err != nil {
+		t.Fatalf("TouchLastUsedEvery: %v", err)
+	}
+	if rec, _ := ReadRecord(dir); !rec.LastUsedAt.After(used) {
+		t.Errorf("LastUsedAt = %v, want updated after interval", rec.LastUsedAt)
+	}
+	if err := TouchLastUsedEvery(t.TempDir(), time.Hour); !errors.Is(err, ErrNoRecord) {
+		t.Errorf("toolchain without record: err = %v, want ErrNoRecord", err)
+	}
+}
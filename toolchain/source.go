@@ -0,0 +1,210 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/x-dvr/gm/progress"
+)
+
+// GoSourceRepo is the upstream git repository of the Go project.
+const GoSourceRepo = "https://go.googlesource.com/go"
+
+// defaultBootstrap is assumed when the source tree does not declare
+// its minimum bootstrap toolchain (releases before Go 1.22).
+const defaultBootstrap = "go1.17.13"
+
+var (
+	reMinBootstrap = regexp.MustCompile(`(?m)^const minBootstrap = "(go[0-9.]+)"`)
+	reNotGo        = regexp.MustCompile(`^notgo1(\d+)\.go$`)
+)
+
+// FetchSource makes sure that repoDir holds a bare clone of repoURL and
+// fetches the given ref (branch, tag, commit or Gerrit change ref) into it.
+// It returns the full commit hash the ref resolves to.
+func FetchSource(repoDir, repoURL, ref string, tracker progress.IOTracker) (string, error) {
+	if _, err := os.Stat(repoDir); err != nil {
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("check source repository %s: %w", repoDir, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(repoDir), 0755); err != nil {
+			return "", fmt.Errorf("create source directory: %w", err)
+		}
+		tracker.Reset(fmt.Sprintf("Cloning %s ...", repoURL))
+		if err := runStreaming(tracker, "", nil, "git", "clone", "--bare", "--progress", repoURL, repoDir); err != nil {
+			return "", fmt.Errorf("clone %s: %w", repoURL, err)
+		}
+	}
+
+	tracker.Reset(fmt.Sprintf("Fetching %s ...", ref))
+	if err := runStreaming(tracker, repoDir, nil, "git", "fetch", "--progress", repoURL, ref); err != nil {
+		return "", fmt.Errorf("fetch %s: %w", ref, err)
+	}
+	out, err := exec.Command("git", "-C", repoDir, "rev-parse", "FETCH_HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CheckoutSource creates a new worktree of repoDir at destPath
+// with the given commit checked out.
+func CheckoutSource(repoDir, commit, destPath string, tracker progress.IOTracker) error {
+	tracker.Reset(fmt.Sprintf("Checking out %s ...", ShortCommit(commit)))
+	if err := runStreaming(tracker, repoDir, nil, "git", "worktree", "add", "--force", "--detach", destPath, commit); err != nil {
+		return fmt.Errorf("checkout %s into %s: %w", commit, destPath, err)
+	}
+	return nil
+}
+
+// UpdateSource switches an existing worktree to the given commit
+// and moves it to destPath, keeping build artifacts for an incremental rebuild.
+// Install record of the worktree is removed, it is not installed until rebuilt.
+func UpdateSource(repoDir, commit, srcPath, destPath string, tracker progress.IOTracker) error {
+	if err := ClearInstalled(srcPath); err != nil {
+		return err
+	}
+	tracker.Reset(fmt.Sprintf("Updating %s to %s ...", filepath.Base(srcPath), ShortCommit(commit)))
+	if err := runStreaming(tracker, srcPath, nil, "git", "checkout", "--force", "--detach", commit); err != nil {
+		return fmt.Errorf("checkout %s in %s: %w", commit, srcPath, err)
+	}
+	if srcPath == destPath {
+		return nil
+	}
+	if err := runStreaming(tracker, repoDir, nil, "git", "worktree", "move", srcPath, destPath); err != nil {
+		return fmt.Errorf("move %s to %s: %w", srcPath, destPath, err)
+	}
+	return nil
+}
+
+// UnpackSource extracts Go source archive (e.g. go1.22.0.src.tar.gz) into destPath.
+func UnpackSource(archiveFile, destPath string, tracker progress.IOTracker) error {
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return fmt.Errorf("create destination directory %s: %w", destPath, err)
+	}
+	if err := unpackArchive(destPath, archiveFile, tracker); err != nil {
+		return fmt.Errorf("extract archive %s: %w", archiveFile, err)
+	}
+	return nil
+}
+
+// BootstrapRequirement returns minimal version of Go toolchain
+// required to build Go source tree in goroot.
+func BootstrapRequirement(goroot string) (string, error) {
+	distDir := filepath.Join(goroot, "src", "cmd", "dist")
+	data, err := os.ReadFile(filepath.Join(distDir, "buildtool.go"))
+	if err != nil {
+		return "", fmt.Errorf("read cmd/dist sources: %w", err)
+	}
+	if m := reMinBootstrap.FindSubmatch(data); m != nil {
+		return string(m[1]), nil
+	}
+
+	entries, err := os.ReadDir(distDir)
+	if err != nil {
+		return "", fmt.Errorf("read cmd/dist sources: %w", err)
+	}
+	for _, entry := range entries {
+		if m := reNotGo.FindStringSubmatch(entry.Name()); m != nil {
+			return "go1." + m[1], nil
+		}
+	}
+	return defaultBootstrap, nil
+}
+
+// BuildFromSource runs make script of Go source tree in goroot using
+// toolchain at bootstrap, streaming build output into the tracker.
+// Built toolchain is marked as installed with the given record.
+func BuildFromSource(goroot, bootstrap string, rec InstallRecord, tracker progress.IOTracker) error {
+	script := "make.bash"
+	if runtime.GOOS == "windows" {
+		script = "make.bat"
+	}
+	srcDir := filepath.Join(goroot, "src")
+	env := append(os.Environ(),
+		"GOROOT_BOOTSTRAP="+bootstrap,
+		"GOROOT=",
+		"GOTOOLCHAIN=local",
+	)
+
+	tracker.Reset(fmt.Sprintf("Building Go using %s ...", bootstrap))
+	if err := runStreaming(tracker, srcDir, env, filepath.Join(srcDir, script)); err != nil {
+		return fmt.Errorf("run %s: %w", script, err)
+	}
+	return MarkInstalled(goroot, rec)
+}
+
+// ShortCommit returns abbreviated form of commit hash.
+func ShortCommit(commit string) string {
+	if len(commit) > 10 {
+		return commit[:10]
+	}
+	return commit
+}
+
+// runStreaming executes command in dir, reporting every line of its
+// combined output to the tracker.
+func runStreaming(tracker progress.IOTracker, dir string, env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	var last string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Split(scanLines)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				last = line
+				tracker.Reset(line)
+			}
+		}
+		io.Copy(io.Discard, pr)
+	}()
+
+	err := cmd.Run()
+	pw.Close()
+	<-done
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && last != "" {
+			return fmt.Errorf("%w: %s", err, last)
+		}
+		return err
+	}
+	return nil
+}
+
+// scanLines is like bufio.ScanLines, but also treats carriage return
+// as line terminator, so that git progress updates are reported.
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	for i, b := range data {
+		if b == '\n' || b == '\r' {
+			return i + 1, data[:i], nil
+		}
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBootstrapRequirement(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "constant",
+			files: map[string]string{
+				"buildtool.go": "package main\n\nconst minBootstrap = \"go1.22.6\"\n",
+				"notgo122.go":  "package main\n",
+			},
+			want: "go1.22.6",
+		},
+		{
+			name: "guard file",
+			files: map[string]string{
+				"buildtool.go": "package main\n",
+				"notgo120.go":  "package main\n",
+			},
+			want: "go1.20",
+		},
+		{
+			name: "default",
+			files: map[string]string{
+				"buildtool.go": "package main\n",
+			},
+			want: defaultBootstrap,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goroot := t.TempDir()
+			distDir := filepath.Join(goroot, "src", "cmd", "dist")
+			if err := os.MkdirAll(distDir, 0755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			for name, contents := range tt.files {
+				if err := os.WriteFile(filepath.Join(distDir, name), []byte(contents), 0644); err != nil {
+					t.Fatalf("write %s: %v", name, err)
+				}
+			}
+
+			got, err := BootstrapRequirement(goroot)
+			if err != nil {
+				t.Fatalf("BootstrapRequirement: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnpackSource(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "go1.22.0.src.tar.gz")
+	if err := writeTarGz(archive, map[string]string{
+		"go/VERSION":         "go1.22.0",
+		"go/src/make.bash":   "#!/bin/sh",
+		"go/src/cmd/go/x.go": "package main",
+	}); err != nil {
+		t.Fatalf("write tar.gz: %v", err)
+	}
+
+	dest := filepath.Join(dir, "go1.22.0")
+	if err := UnpackSource(archive, dest, nopTracker{}); err != nil {
+		t.Fatalf("UnpackSource: %v", err)
+	}
+	if got, err := ReadVersion(dest); err != nil || got != "go1.22.0" {
+		t.Errorf("ReadVersion = %q, %v, want go1.22.0", got, err)
+	}
+}
+
+func TestScanLines(t *testing.T) {
+	input := "Receiving objects:  10%\rReceiving objects: 100%\nBuilding Go cmd/dist\n\nlast"
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(scanLines)
+
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+	want := []string{"Receiving objects:  10%", "Receiving objects: 100%", "Building Go cmd/dist", "", "last"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestShortCommit(t *testing.T) {
+	if got := ShortCommit("0123456789abcdef"); got != "0123456789" {
+		t.Errorf("got %q, want 0123456789", got)
+	}
+	if got := ShortCommit("abc"); got != "abc" {
+		t.Errorf("got %q, want abc", got)
+	}
+}
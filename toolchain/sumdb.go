@@ -0,0 +1,297 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const defaultSumDB = "sum.golang.org"
+
+// knownSumDB holds verifier keys of well-known checksum databases, same as the go command.
+var knownSumDB = map[string]string{
+	"sum.golang.org": "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8",
+}
+
+var (
+	ErrSumDBDisabled = errors.New("checksum database is disabled")
+	ErrNotInSumDB    = errors.New("module version not found in checksum database")
+)
+
+// SumDB is a client of Go checksum database.
+type SumDB struct {
+	name   string
+	client *sumdb.Client
+	ops    *sumdbOps
+}
+
+// NewSumDB creates client of checksum database described by gosumdb
+// in GOSUMDB format ("name", "key" or "key url"). Verified tree heads
+// and tiles are cached in cacheDir. If URL is not given, database is accessed
+// through the first of proxies supporting it, or directly otherwise.
+func NewSumDB(gosumdb, cacheDir string, proxies []string) (*SumDB, error) {
+	if gosumdb == "" {
+		gosumdb = defaultSumDB
+	}
+	if gosumdb == "sum.golang.google.cn" {
+		gosumdb = "sum.golang.org https://sum.golang.google.cn"
+	}
+	if gosumdb == "off" {
+		return nil, ErrSumDBDisabled
+	}
+
+	fields := strings.Fields(gosumdb)
+	if len(fields) > 2 {
+		return nil, fmt.Errorf("invalid GOSUMDB %q: too many fields", gosumdb)
+	}
+	key := fields[0]
+	if k, ok := knownSumDB[key]; ok {
+		key = k
+	}
+	verifier, err := note.NewVerifier(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GOSUMDB key: %w", err)
+	}
+	name := verifier.Name()
+
+	var base string
+	if len(fields) == 2 {
+		base = fields[1]
+	} else {
+		base = sumdbViaProxy(name, proxies)
+	}
+	if _, err := url.Parse(base); err != nil {
+		return nil, fmt.Errorf("invalid GOSUMDB URL: %w", err)
+	}
+
+	ops := &sumdbOps{
+		key:      key,
+		base:     strings.TrimSuffix(base, "/"),
+		cacheDir: cacheDir,
+	}
+	return &SumDB{name: name, client: sumdb.NewClient(ops), ops: ops}, nil
+}
+
+// Name returns name of checksum database.
+func (db *SumDB) Name() string {
+	return db.name
+}
+
+// SetNoSumDB sets comma-separated GONOSUMDB patterns of modules not checked in database.
+func (db *SumDB) SetNoSumDB(patterns string) {
+	if patterns != "" {
+		db.client.SetGONOSUMDB(patterns)
+	}
+}
+
+// Lookup returns h1 hash of module zip recorded in checksum database.
+func (db *SumDB) Lookup(mod module.Version) (string, error) {
+	lines, err := db.client.Lookup(mod.Path, mod.Version)
+	if err != nil {
+		if errors.Is(err, sumdb.ErrGONOSUMDB) {
+			return "", ErrSumDBDisabled
+		}
+		if msg := db.ops.securityError(); msg != "" {
+			return "", fmt.Errorf("%w: %s", sumdb.ErrSecurity, msg)
+		}
+		if db.ops.notFound(mod) {
+			return "", fmt.Errorf("%w: %s", ErrNotInSumDB, mod)
+		}
+		return "", err
+	}
+	for _, line := range lines {
+		if f := strings.Fields(line); len(f) == 3 {
+			return f[2], nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotInSumDB, mod)
+}
+
+// Verify checks that h1 hash of module zip is recorded in checksum database.
+func (db *SumDB) Verify(mod module.Version, zipFile string) error {
+	want, err := db.Lookup(mod)
+	if err != nil {
+		return err
+	}
+	got, err := HashModuleZip(zipFile)
+	if err != nil {
+		return fmt.Errorf("hash module zip: %w", err)
+	}
+	return db.compare(mod, got, want)
+}
+
+// VerifyDir checks that toolchain installed into dir has the same h1 hash
+// as toolchain module mod recorded in checksum database.
+func (db *SumDB) VerifyDir(mod module.Version, dir string) error {
+	want, err := db.Lookup(mod)
+	if err != nil {
+		return err
+	}
+	got, err := HashModuleDir(dir, mod)
+	if err != nil {
+		return fmt.Errorf("hash toolchain files: %w", err)
+	}
+	return db.compare(mod, got, want)
+}
+
+func (db *SumDB) compare(mod module.Version, got, want string) error {
+	if got != want {
+		return fmt.Errorf("%w: %s has %s, checksum database (%s) has %s", ErrChecksumMismatch, mod, got, db.name, want)
+	}
+	return nil
+}
+
+// sumdbViaProxy returns URL of checksum database served by the first proxy
+// which supports it, or direct URL of database.
+func sumdbViaProxy(name string, proxies []string) string {
+	for _, proxy := range proxies {
+		res, err := httpClient.Get(proxy + "/sumdb/" + name + "/supported")
+		if err != nil {
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode == http.StatusOK {
+			return proxy + "/sumdb/" + name
+		}
+	}
+	return "https://" + name
+}
+
+// sumdbOps implements access to remote checksum database and local cache.
+type sumdbOps struct {
+	key      string
+	base     string
+	cacheDir string
+
+	mu       sync.Mutex
+	security string
+	// missing holds lookup paths answered with "not found"
+	missing map[string]bool
+}
+
+var _ sumdb.ClientOps = (*sumdbOps)(nil)
+
+func (o *sumdbOps) ReadRemote(path string) ([]byte, error) {
+	target := o.base + path
+	res, err := httpClient.Get(target)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+			o.mu.Lock()
+			if o.missing == nil {
+				o.missing = map[string]bool{}
+			}
+			o.missing[path] = true
+			o.mu.Unlock()
+		}
+		return nil, &statusError{url: target, status: res.Status, code: res.StatusCode}
+	}
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
+
+func (o *sumdbOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(o.key), nil
+	}
+	data, err := os.ReadFile(o.path("config", file))
+	if os.IsNotExist(err) {
+		// start with empty tree
+		return nil, nil
+	}
+	return data, err
+}
+
+func (o *sumdbOps) WriteConfig(file string, old, new []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	path := o.path("config", file)
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !bytes.Equal(current, old) {
+		return sumdb.ErrWriteConflict
+	}
+	return writeFileAtomic(path, new)
+}
+
+func (o *sumdbOps) ReadCache(file string) ([]byte, error) {
+	return os.ReadFile(o.path("cache", file))
+}
+
+func (o *sumdbOps) WriteCache(file string, data []byte) {
+	// cache is an optimization, ignore failures
+	writeFileAtomic(o.path("cache", file), data)
+}
+
+func (o *sumdbOps) Log(string) {}
+
+func (o *sumdbOps) SecurityError(msg string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.security = msg
+}
+
+func (o *sumdbOps) securityError() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.security
+}
+
+// notFound reports whether database has no record of mod.
+func (o *sumdbOps) notFound(mod module.Version) bool {
+	epath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return false
+	}
+	evers, err := module.EscapeVersion(mod.Version)
+	if err != nil {
+		return false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.missing["/lookup/"+epath+"@"+evers]
+}
+
+func (o *sumdbOps) path(kind, file string) string {
+	return filepath.Join(o.cacheDir, kind, filepath.FromSlash(file))
+}
+
+// writeFileAtomic replaces contents of the file, creating parent directories if needed.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
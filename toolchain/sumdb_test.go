@@ -0,0 +1,178 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// testSumDB starts checksum database serving the given hashes (module@version -> h1 hash).
+// It returns value for GOSUMDB.
+func testSumDB(t *testing.T, hashes map[string]string) string {
+	t.Helper()
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sumdb.test")
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	gosum := func(path, vers string) ([]byte, error) {
+		h, ok := hashes[path+"@"+vers]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(path + " " + vers + " " + h + "\n" + path + " " + vers + "/go.mod h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=\n"), nil
+	}
+	srv := httptest.NewServer(sumdb.NewServer(sumdb.NewTestServer(skey, gosum)))
+	t.Cleanup(srv.Close)
+	return vkey + " " + srv.URL
+}
+
+// releaseFiles returns files of binary release archive of version. Directories
+// api, doc, misc and test are part of the archive but not of toolchain module.
+func releaseFiles(version string) map[string]string {
+	return map[string]string{
+		"VERSION":                version,
+		"bin/go":                 "binary",
+		"src/go.mod":             "module std\n",
+		"src/cmd/api/main.go":    "package main\n",
+		"api/go1.txt":            "pkg bufio, const MaxScanTokenSize = 65536\n",
+		"doc/go_spec.html":       "<!DOCTYPE html>\n",
+		"misc/wasm/wasm_exec.js": "\"use strict\";\n",
+		"test/run.go":            "// run\n",
+		"test/go.mod":            "module test\n",
+	}
+}
+
+// distpackHash returns h1 hash of toolchain module mod made of files the way
+// cmd/distpack does: excluded directories are dropped, go.mod files renamed.
+func distpackHash(t *testing.T, mod module.Version, files map[string]string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "module.zip")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, contents := range files {
+		switch top, _, _ := strings.Cut(name, "/"); top {
+		case "api", "doc", "misc", "test":
+			continue
+		}
+		if path.Base(name) == "go.mod" {
+			name = path.Join(path.Dir(name), "_go.mod")
+		}
+		w, err := zw.Create(mod.Path + "@" + mod.Version + "/" + name)
+		if err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(contents)); err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	f.Close()
+	h, err := dirhash.HashZip(file, dirhash.Hash1)
+	if err != nil {
+		t.Fatalf("HashZip: %v", err)
+	}
+	return h
+}
+
+// writeReleaseTree creates toolchain tree as unpacked from binary release archive
+// and returns it along with the hash of corresponding toolchain module.
+func writeReleaseTree(t *testing.T, version string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := releaseFiles(version)
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir, distpackHash(t, ModuleVersion(version), files)
+}
+
+func TestHashModuleDir_Release(t *testing.T) {
+	// hash of fixture as published for linux/amd64, computed with dirhash.HashZip
+	const want = "h1:Z5hKhV+JVnsUQNaapa/UcAvT5nxFn4GhP0hOUuO0dc4="
+	dir, _ := writeReleaseTree(t, "go1.22.4")
+	mod := module.Version{Path: ToolchainModule, Version: "v0.0.1-go1.22.4.linux-amd64"}
+
+	if h := distpackHash(t, mod, releaseFiles("go1.22.4")); h != want {
+		t.Fatalf("hash of distpack zip = %s, want %s", h, want)
+	}
+	h, err := HashModuleDir(dir, mod)
+	if err != nil {
+		t.Fatalf("HashModuleDir: %v", err)
+	}
+	if h != want {
+		t.Errorf("HashModuleDir = %s, want %s", h, want)
+	}
+}
+
+func TestNewSumDB_Off(t *testing.T) {
+	if _, err := NewSumDB("off", t.TempDir(), nil); !errors.Is(err, ErrSumDBDisabled) {
+		t.Errorf("err = %v, want ErrSumDBDisabled", err)
+	}
+	if _, err := NewSumDB("not a key", t.TempDir(), nil); err == nil {
+		t.Error("invalid GOSUMDB must be rejected")
+	}
+}
+
+func TestSumDB_VerifyDir(t *testing.T) {
+	dir, h := writeReleaseTree(t, "go1.22.4")
+	mod := ModuleVersion("go1.22.4")
+	gosumdb := testSumDB(t, map[string]string{mod.Path + "@" + mod.Version: h})
+
+	db, err := NewSumDB(gosumdb, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewSumDB: %v", err)
+	}
+	if db.Name() != "sumdb.test" {
+		t.Errorf("Name = %q", db.Name())
+	}
+	if err := db.VerifyDir(mod, dir); err != nil {
+		t.Errorf("VerifyDir: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "bin", "go"), []byte("tampered"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := db.VerifyDir(mod, dir); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("VerifyDir of modified tree: err = %v, want ErrChecksumMismatch", err)
+	}
+
+	if _, err := db.Lookup(ModuleVersion("go1.20.0")); !errors.Is(err, ErrNotInSumDB) {
+		t.Errorf("Lookup of unknown version: err = %v, want ErrNotInSumDB", err)
+	}
+}
+
+func TestSumDB_NoSumDB(t *testing.T) {
+	db, err := NewSumDB(testSumDB(t, nil), t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("NewSumDB: %v", err)
+	}
+	db.SetNoSumDB("golang.org/toolchain")
+	if _, err := db.Lookup(ModuleVersion("go1.22.4")); !errors.Is(err, ErrSumDBDisabled) {
+		t.Errorf("err = %v, want ErrSumDBDisabled", err)
+	}
+}
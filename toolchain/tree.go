@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+var ErrNotToolchain = errors.New("not a Go toolchain")
+
+// ReadVersion returns version of Go tree in goroot as recorded in its VERSION file.
+// Development trees have no VERSION file, in this case empty string is returned.
+func ReadVersion(goroot string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(goroot, "VERSION"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	version, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimSpace(version), nil
+}
+
+// CheckTree verifies that goroot contains Go toolchain.
+func CheckTree(goroot string) error {
+	fi, err := os.Stat(GoBinary(goroot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s has no go binary", ErrNotToolchain, goroot)
+		}
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("%w: %s has no go binary", ErrNotToolchain, goroot)
+	}
+	return nil
+}
+
+// GoBinary returns path of go command of toolchain in goroot.
+func GoBinary(goroot string) string {
+	name := "go"
+	if runtime.GOOS == "windows" {
+		name = "go.exe"
+	}
+	return filepath.Join(goroot, "bin", name)
+}
+
+// DiskUsage returns total size of regular files in dir.
+func DiskUsage(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += fi.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("compute disk usage of %s: %w", dir, err)
+	}
+	return size, nil
+}
+
+// isStoreFile reports whether file at rel path (slash-separated) of toolchain tree
+// was created by gm and does not belong to Go distribution.
+func isStoreFile(rel string) bool {
+	if strings.Contains(rel, "/") {
+		return false
+	}
+	if rel == installSuccessMarker || strings.HasPrefix(rel, ".gm-") {
+		return true
+	}
+	return isReleaseArchive(rel)
+}
+
+// isReleaseArchive reports whether name is a name of binary release archive downloaded by Install.
+func isReleaseArchive(name string) bool {
+	return strings.HasPrefix(name, "go") && (strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".zip"))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package toolchain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadVersion(t *testing.T) {
+	goroot := t.TempDir()
+
+	got, err := ReadVersion(goroot)
+	if err != nil {
+		t.Fatalf("ReadVersion (no file): %v", err)
+	}
+	if got != "" {
+		t.Errorf("got %q, want empty version for development tree", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(goroot, "VERSION"), []byte("go1.22.0\ntime 2024-02-06T21:55:19Z\n"), 0644); err != nil {
+		t.Fatalf("write VERSION: %v", err)
+	}
+	got, err = ReadVersion(goroot)
+	if err != nil {
+		t.Fatalf("ReadVersion: %v", err)
+	}
+	if got != "go1.22.0" {
+		t.Errorf("got %q, want %q", got, "go1.22.0")
+	}
+}
+
+func TestCheckTree(t *testing.T) {
+	goroot := t.TempDir()
+	if err := CheckTree(goroot); !errors.Is(err, ErrNotToolchain) {
+		t.Errorf("err = %v, want ErrNotToolchain for empty directory", err)
+	}
+
+	bin := GoBinary(goroot)
+	if err := os.MkdirAll(filepath.Dir(bin), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(bin, []byte("binary"), 0755); err != nil {
+		t.Fatalf("write go binary: %v", err)
+	}
+	if err := CheckTree(goroot); err != nil {
+		t.Errorf("CheckTree: %v", err)
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	goroot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(goroot, "bin"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for name, size := range map[string]int{"VERSION": 10, "bin/go": 100} {
+		if err := os.WriteFile(filepath.Join(goroot, filepath.FromSlash(name)), make([]byte, size), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	got, err := DiskUsage(goroot)
+	if err != nil {
+		t.Fatalf("DiskUsage: %v", err)
+	}
+	if got != 110 {
+		t.Errorf("DiskUsage = %d, want 110", got)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package vuln
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/x-dvr/gm/gover"
+)
+
+// DefaultDB is Go vulnerability database.
+const DefaultDB = "https://vuln.go.dev"
+
+var ErrNotCached = errors.New("vulnerability database is not cached")
+
+// Advisory is a vulnerability affecting Go version.
+type Advisory struct {
+	ID      string
+	Aliases []string
+	Summary string
+	// Module is StdlibModule or ToolchainModule
+	Module string
+	// Packages are affected packages of the module, empty if all are affected
+	Packages []string
+	// Fixed is the first Go version fixing the vulnerability, empty if there is no fix
+	Fixed string
+}
+
+// Client reads Go vulnerability database in OSV format served over HTTP(S)
+// or stored in local directory (file:// URL).
+type Client struct {
+	base     *url.URL
+	cacheDir string
+	// offline client reads only data cached by previous requests
+	offline bool
+	http    *http.Client
+}
+
+// NewClient returns client of vulnerability database at db URL. Data fetched over
+// network is cached in cacheDir. Offline client never fetches data, it only reads cache.
+func NewClient(db, cacheDir string, offline bool) (*Client, error) {
+	base, err := url.Parse(strings.TrimSuffix(db, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse vulnerability database URL: %w", err)
+	}
+	switch base.Scheme {
+	case "http", "https", "file":
+	default:
+		return nil, fmt.Errorf("unsupported vulnerability database URL %q (supported: http, https, file)", db)
+	}
+	return &Client{
+		base:     base,
+		cacheDir: filepath.Join(cacheDir, base.Scheme, base.Host, filepath.FromSlash(base.Path)),
+		offline:  offline,
+		http:     &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+// dbMeta is a content of index/db.json.
+type dbMeta struct {
+	Modified time.Time `json:"modified"`
+}
+
+// moduleIndex is an entry of index/modules.json.
+type moduleIndex struct {
+	Path  string     `json:"path"`
+	Vulns []vulnMeta `json:"vulns"`
+}
+
+type vulnMeta struct {
+	ID       string    `json:"id"`
+	Modified time.Time `json:"modified"`
+	// Fixed is the latest version fixing vulnerability
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// Check returns advisories of standard library and toolchain vulnerabilities
+// affecting each of the given Go versions. Versions which are not Go releases are skipped.
+func (c *Client) Check(versions []string) (map[string][]Advisory, error) {
+	semvers := make(map[string]string, len(versions))
+	for _, v := range versions {
+		if gv, err := gover.Parse(v); err == nil {
+			semvers[v] = gv.Semver()
+		}
+	}
+	if len(semvers) == 0 {
+		return map[string][]Advisory{}, nil
+	}
+
+	index, err := c.modules()
+	if err != nil {
+		return nil, err
+	}
+	oldest := slices.MinFunc(slices.Collect(maps.Values(semvers)), semver.Compare)
+
+	result := make(map[string][]Advisory, len(semvers))
+	for _, mod := range index {
+		if mod.Path != StdlibModule && mod.Path != ToolchainModule {
+			continue
+		}
+		for _, meta := range mod.Vulns {
+			if meta.Fixed != "" && semver.Compare(oldest, "v"+meta.Fixed) >= 0 {
+				// all checked versions are newer than the latest fix
+				continue
+			}
+			entry, err := c.entry(meta)
+			if err != nil {
+				return nil, err
+			}
+			for version, sv := range semvers {
+				if adv, ok := advisory(entry, mod.Path, sv); ok {
+					result[version] = append(result[version], adv)
+				}
+			}
+		}
+	}
+	for _, advs := range result {
+		slices.SortFunc(advs, func(a, b Advisory) int { return strings.Compare(a.ID, b.ID) })
+	}
+	return result, nil
+}
+
+// advisory checks whether semantic version sv of module is affected by entry.
+func advisory(entry *Entry, module, sv string) (Advisory, bool) {
+	for _, a := range entry.Affected {
+		if a.Module.Path != module {
+			continue
+		}
+		affected, fixed := a.affects(sv)
+		if !affected {
+			continue
+		}
+		adv := Advisory{
+			ID:      entry.ID,
+			Aliases: entry.Aliases,
+			Summary: entry.Summary,
+			Module:  module,
+		}
+		if v, err := gover.FromSemver(fixed); err == nil {
+			adv.Fixed = v.String()
+		}
+		for _, p := range a.EcosystemSpecific.Packages {
+			adv.Packages = append(adv.Packages, p.Path)
+		}
+		return adv, true
+	}
+	return Advisory{}, false
+}
+
+// modules returns index of modules, refreshed when database was modified.
+func (c *Client) modules() ([]moduleIndex, error) {
+	var meta dbMeta
+	if err := c.get("index/db.json", func([]byte) bool { return false }, &meta); err != nil {
+		return nil, err
+	}
+	var index []moduleIndex
+	// cached index fetched after the last modification of database is up to date
+	cacheFile := filepath.Join(c.cacheDir, "index", "modules.json")
+	fresh := func([]byte) bool {
+		fi, err := os.Stat(cacheFile)
+		return err == nil && !fi.ModTime().Before(meta.Modified)
+	}
+	if err := c.get("index/modules.json", fresh, &index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// entry returns vulnerability report, cached report is used unless it was modified.
+func (c *Client) entry(meta vulnMeta) (*Entry, error) {
+	var e Entry
+	fresh := func(cached []byte) bool {
+		var old Entry
+		return json.Unmarshal(cached, &old) == nil && old.Modified.Equal(meta.Modified)
+	}
+	if err := c.get("ID/"+meta.ID+".json", fresh, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// get decodes JSON document at path of database into v. Cached copy of document
+// is used if fresh reports so, or when client is offline or database is unavailable.
+func (c *Client) get(path string, fresh func(cached []byte) bool, v any) error {
+	if c.base.Scheme == "file" {
+		data, err := os.ReadFile(filepath.Join(filepath.FromSlash(c.base.Path), filepath.FromSlash(path)))
+		if err != nil {
+			return fmt.Errorf("read vulnerability database: %w", err)
+		}
+		return decode(path, data, v)
+	}
+
+	cacheFile := filepath.Join(c.cacheDir, filepath.FromSlash(path))
+	cached, cacheErr := os.ReadFile(cacheFile)
+	if c.offline {
+		if cacheErr != nil {
+			return fmt.Errorf("%w: %s", ErrNotCached, path)
+		}
+		return decode(path, cached, v)
+	}
+	if cacheErr == nil && fresh(cached) {
+		return decode(path, cached, v)
+	}
+
+	data, err := c.fetch(path)
+	if err != nil {
+		if cacheErr == nil {
+			return decode(path, cached, v)
+		}
+		return err
+	}
+	if err := decode(path, data, v); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0755); err != nil {
+		return fmt.Errorf("cache vulnerability database: %w", err)
+	}
+	if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+		return fmt.Errorf("cache vulnerability database: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) fetch(path string) ([]byte, error) {
+	target := c.base.String() + "/" + path
+	res, err := c.http.Get(target)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", target, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: %s", target, res.Status)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", target, err)
+	}
+	return data, nil
+}
+
+func decode(path string, data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
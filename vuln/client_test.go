@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package vuln
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+var dbModified = time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)
+
+// writeTestDB creates vulnerability database in dir with two stdlib
+// and one toolchain vulnerability.
+func writeTestDB(t *testing.T, dir string) {
+	t.Helper()
+	entries := []Entry{
+		{
+			ID: "GO-2024-2887", Modified: dbModified, Aliases: []string{"CVE-2024-24790"},
+			Summary: "Unexpected behavior from Is methods for IPv4-mapped IPv6 addresses in net/netip",
+			Affected: []Affected{{
+				Module:            Module{Path: StdlibModule, Ecosystem: "Go"},
+				Ranges:            []Range{{Type: "SEMVER", Events: []Event{{Introduced: "0"}, {Fixed: "1.21.11"}, {Introduced: "1.22.0-0"}, {Fixed: "1.22.4"}}}},
+				EcosystemSpecific: EcosystemSpecific{Packages: []Package{{Path: "net/netip"}}},
+			}},
+		},
+		{
+			ID: "GO-2023-1878", Modified: dbModified,
+			Affected: []Affected{{
+				Module: Module{Path: StdlibModule, Ecosystem: "Go"},
+				Ranges: []Range{{Type: "SEMVER", Events: []Event{{Introduced: "0"}, {Fixed: "1.19.10"}, {Introduced: "1.20.0-0"}, {Fixed: "1.20.5"}}}},
+			}},
+		},
+		{
+			ID: "GO-2024-2963", Modified: dbModified,
+			Affected: []Affected{{
+				Module: Module{Path: ToolchainModule, Ecosystem: "Go"},
+				Ranges: []Range{{Type: "SEMVER", Events: []Event{{Introduced: "1.22.0-0"}, {Fixed: "1.22.5"}}}},
+			}},
+		},
+	}
+	index := []moduleIndex{
+		{Path: StdlibModule, Vulns: []vulnMeta{
+			{ID: "GO-2024-2887", Modified: dbModified, Fixed: "1.22.4"},
+			{ID: "GO-2023-1878", Modified: dbModified, Fixed: "1.20.5"},
+		}},
+		{Path: ToolchainModule, Vulns: []vulnMeta{{ID: "GO-2024-2963", Modified: dbModified, Fixed: "1.22.5"}}},
+		{Path: "golang.org/x/net", Vulns: []vulnMeta{{ID: "GO-2024-0000", Modified: dbModified}}},
+	}
+
+	write := func(name string, v any) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	write("index/db.json", dbMeta{Modified: dbModified})
+	write("index/modules.json", index)
+	for _, e := range entries {
+		write("ID/"+e.ID+".json", e)
+	}
+}
+
+func TestCheck_File(t *testing.T) {
+	dir := t.TempDir()
+	writeTestDB(t, dir)
+	c, err := NewClient((&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String(), t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	got, err := c.Check([]string{"1.22.3", "go1.22.5", "1.20.4", "tip-0123456789"})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	ids := func(version string) []string {
+		var ids []string
+		for _, adv := range got[version] {
+			ids = append(ids, adv.ID+"@"+adv.Fixed)
+		}
+		return ids
+	}
+	if want := []string{"GO-2024-2887@go1.22.4", "GO-2024-2963@go1.22.5"}; !slices.Equal(ids("1.22.3"), want) {
+		t.Errorf("1.22.3 advisories = %v, want %v", ids("1.22.3"), want)
+	}
+	if want := []string{"GO-2023-1878@go1.20.5", "GO-2024-2887@go1.21.11"}; !slices.Equal(ids("1.20.4"), want) {
+		t.Errorf("1.20.4 advisories = %v, want %v", ids("1.20.4"), want)
+	}
+	if len(got["go1.22.5"]) != 0 || len(got["tip-0123456789"]) != 0 {
+		t.Errorf("unexpected advisories: %v", got)
+	}
+	if adv := got["1.22.3"][0]; len(adv.Packages) != 1 || adv.Packages[0] != "net/netip" || adv.Aliases[0] != "CVE-2024-24790" {
+		t.Errorf("advisory = %+v", adv)
+	}
+}
+
+func TestCheck_HTTPCache(t *testing.T) {
+	dir := t.TempDir()
+	writeTestDB(t, dir)
+	requests := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		http.FileServer(http.Dir(dir)).ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	cacheDir := t.TempDir()
+	c, err := NewClient(srv.URL, cacheDir, false)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	for range 2 {
+		got, err := c.Check([]string{"1.22.3"})
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if len(got["1.22.3"]) != 2 {
+			t.Errorf("advisories = %+v, want 2", got["1.22.3"])
+		}
+	}
+	if requests["/index/modules.json"] != 1 || requests["/ID/GO-2024-2887.json"] != 1 {
+		t.Errorf("requests = %v, want unmodified data to be read from cache", requests)
+	}
+	if requests["/ID/GO-2023-1878.json"] != 0 {
+		t.Errorf("requests = %v, want vulnerabilities fixed before checked versions to be skipped", requests)
+	}
+
+	srv.Close()
+	offline, err := NewClient(srv.URL, cacheDir, true)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if got, err := offline.Check([]string{"1.22.3"}); err != nil || len(got["1.22.3"]) != 2 {
+		t.Errorf("offline Check = %v, %v, want cached advisories", got, err)
+	}
+	if _, err := offline.Check([]string{"1.20.4"}); !errors.Is(err, ErrNotCached) {
+		t.Errorf("err = %v, want ErrNotCached for data never fetched", err)
+	}
+}
+
+func TestNewClient_UnsupportedScheme(t *testing.T) {
+	if _, err := NewClient("ftp://vuln.example", t.TempDir(), false); err == nil {
+		t.Error("NewClient: want error for unsupported scheme")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package vuln
+
+import (
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// StdlibModule is a module path of standard library vulnerabilities
+	StdlibModule = "stdlib"
+	// ToolchainModule is a module path of go command and other tools vulnerabilities
+	ToolchainModule = "toolchain"
+)
+
+// Entry is a vulnerability report in OSV format.
+type Entry struct {
+	ID       string     `json:"id"`
+	Modified time.Time  `json:"modified"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Affected []Affected `json:"affected"`
+}
+
+// Affected describes versions and packages of a module affected by vulnerability.
+type Affected struct {
+	Module            Module            `json:"package"`
+	Ranges            []Range           `json:"ranges,omitempty"`
+	EcosystemSpecific EcosystemSpecific `json:"ecosystem_specific"`
+}
+
+type Module struct {
+	Path      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+// Range is a list of events in semantic versions without "v" prefix.
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event either introduces vulnerability or fixes it, "0" is introduced in the first version.
+type Event struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+type EcosystemSpecific struct {
+	Packages []Package `json:"imports,omitempty"`
+}
+
+type Package struct {
+	Path string `json:"path"`
+}
+
+// affects reports whether semantic version v of module is affected by vulnerability.
+// It also returns the first version fixing it, empty if there is no fix.
+func (a Affected) affects(v string) (bool, string) {
+	if len(a.Ranges) == 0 {
+		// no ranges mean all versions are affected
+		return true, ""
+	}
+	for _, r := range a.Ranges {
+		if r.Type != "SEMVER" {
+			continue
+		}
+		affected := false
+		for _, e := range r.Events {
+			switch {
+			case e.Introduced != "":
+				if e.Introduced == "0" || semver.Compare(v, "v"+e.Introduced) >= 0 {
+					affected = true
+				}
+			case e.Fixed != "":
+				if semver.Compare(v, "v"+e.Fixed) < 0 {
+					if affected {
+						return true, e.Fixed
+					}
+				} else {
+					affected = false
+				}
+			}
+		}
+		if affected {
+			return true, ""
+		}
+	}
+	return false, ""
+}
@@ -0,0 +1,44 @@
+/*
+Copyright © 2025 DENIS RODIN <denis.rodin@proton.me>
+*/
+package vuln
+
+import "testing"
+
+func TestAffects(t *testing.T) {
+	a := Affected{
+		Module: Module{Path: StdlibModule},
+		Ranges: []Range{{Type: "SEMVER", Events: []Event{
+			{Introduced: "0"},
+			{Fixed: "1.21.12"},
+			{Introduced: "1.22.0-0"},
+			{Fixed: "1.22.5"},
+		}}},
+	}
+	tests := []struct {
+		version   string
+		affected  bool
+		wantFixed string
+	}{
+		{"v1.20.3", true, "1.21.12"},
+		{"v1.21.12", false, ""},
+		{"v1.22.0-rc.1", true, "1.22.5"},
+		{"v1.22.4", true, "1.22.5"},
+		{"v1.22.5", false, ""},
+		{"v1.23.0", false, ""},
+	}
+	for _, tt := range tests {
+		affected, fixed := a.affects(tt.version)
+		if affected != tt.affected || fixed != tt.wantFixed {
+			t.Errorf("affects(%s) = %v, %q, want %v, %q", tt.version, affected, fixed, tt.affected, tt.wantFixed)
+		}
+	}
+
+	unfixed := Affected{Ranges: []Range{{Type: "SEMVER", Events: []Event{{Introduced: "1.22.0"}}}}}
+	if affected, fixed := unfixed.affects("v1.23.1"); !affected || fixed != "" {
+		t.Errorf("affects = %v, %q, want affected without fix", affected, fixed)
+	}
+	if affected, _ := unfixed.affects("v1.21.1"); affected {
+		t.Error("version before introduced must not be affected")
+	}
+}